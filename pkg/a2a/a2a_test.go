@@ -0,0 +1,403 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package a2a
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/efile"
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type transportFunc func(req *http.Request) (*http.Response, error)
+
+func (f transportFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testdata(t *testing.T, name string) []byte {
+	buf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", name))
+	assert.Equal(t, nil, err)
+	return buf
+}
+
+func zipFile(t *testing.T, name string, data []byte) []byte {
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	f, err := writer.Create(name)
+	assert.Equal(t, nil, err)
+	_, err = f.Write(data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, writer.Close())
+	return buf.Bytes()
+}
+
+// stubResponse answers with a SOAP envelope of the body, the attachment is sent as second MIME part
+func stubResponse(t *testing.T, status int, body string, attachment []byte) *http.Response {
+	envelope := fmt.Sprintf(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="%s"><SOAP-ENV:Body>%s</SOAP-ENV:Body></SOAP-ENV:Envelope>`, efile.SoapEnvNamespace, body)
+	resp := &http.Response{StatusCode: status, Status: http.StatusText(status), Header: http.Header{}}
+	if attachment == nil {
+		resp.Header.Set("Content-Type", "text/xml; charset=UTF-8")
+		resp.Body = io.NopCloser(strings.NewReader(envelope))
+		return resp
+	}
+	contentType, buf, err := utils.MultipartMessage([]byte(envelope), attachment, nil)
+	assert.Equal(t, nil, err)
+	resp.Header.Set("Content-Type", contentType)
+	resp.Body = io.NopCloser(bytes.NewReader(buf))
+	return resp
+}
+
+func TestClient(t *testing.T) {
+	acks := testdata(t, "irs990_acknowledgement_list.xml")
+	receipts := `<SubmissionReceiptList xmlns="http://www.irs.gov/efile"><Cnt>1</Cnt><SubmissionReceiptGrp>` +
+		`<SubmissionId>0123456789123adcdefg</SubmissionId><SubmissionReceivedTs>2020-10-26T07:28:40Z</SubmissionReceivedTs>` +
+		`</SubmissionReceiptGrp></SubmissionReceiptList>`
+	status := `<StatusRecordList xmlns="http://www.irs.gov/efile"><Cnt>1</Cnt><StatusRecordGrp><SubmissionId>00000020193037000001</SubmissionId>` +
+		`<SubmissionStatusTxt>Accepted Acknowledgement Created</SubmissionStatusTxt><SubmsnStatusAcknowledgementDt>2019-11-02</SubmsnStatusAcknowledgementDt>` +
+		`</StatusRecordGrp></StatusRecordList>`
+	notifications := `<AckNotificationList xmlns="http://www.irs.gov/efile"><Cnt>1</Cnt><AckNotification>` +
+		`<SubmissionId>00000020193037000001</SubmissionId><Ts>2019-11-02T10:00:00Z</Ts></AckNotification></AckNotificationList>`
+
+	var actions []string
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		action := req.Header.Get("SOAPAction")
+		actions = append(actions, action)
+		assert.True(t, strings.HasSuffix(req.URL.Path, "/"+action))
+
+		buf, err := io.ReadAll(req.Body)
+		assert.Equal(t, nil, err)
+		if action != SendSubmissionsAction {
+			var envelope struct {
+				Header struct {
+					MeFHeader MeFHeader
+				}
+			}
+			assert.Equal(t, nil, xml.Unmarshal(buf, &envelope))
+			assert.Equal(t, action, envelope.Header.MeFHeader.Action)
+			assert.Equal(t, "T", envelope.Header.MeFHeader.TestCd)
+			assert.Equal(t, nil, envelope.Header.MeFHeader.Validate())
+		}
+		if action != LoginAction {
+			cookie, err := req.Cookie("JSESSIONID")
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "session", cookie.Value)
+		}
+
+		tns := `xmlns="` + TransmitterNamespace + `"`
+		switch action {
+		case LoginAction:
+			resp := stubResponse(t, http.StatusOK, `<LoginResponse xmlns="`+MSIServicesNamespace+`"><StatusTxt>Success</StatusTxt></LoginResponse>`, nil)
+			resp.Header.Add("Set-Cookie", "JSESSIONID=session")
+			return resp, nil
+		case LogoutAction:
+			return stubResponse(t, http.StatusOK, `<LogoutResponse xmlns="`+MSIServicesNamespace+`"><StatusTxt>Success</StatusTxt></LogoutResponse>`, nil), nil
+		case SendSubmissionsAction:
+			assert.True(t, strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/related"))
+			assert.True(t, bytes.Contains(buf, []byte("SendSubmissionsRequest")))
+			return stubResponse(t, http.StatusOK, `<SendSubmissionsResponse `+tns+`/>`, zipFile(t, "receipts.xml", []byte(receipts))), nil
+		case GetNewAcksAction:
+			return stubResponse(t, http.StatusOK, `<GetNewAcksResponse `+tns+`><MoreAvailableInd>true</MoreAvailableInd></GetNewAcksResponse>`, zipFile(t, "acks.xml", acks)), nil
+		case GetAcksAction, GetAckAction:
+			return stubResponse(t, http.StatusOK, `<`+action+`Response `+tns+`/>`, zipFile(t, "acks.xml", acks)), nil
+		case GetSubmissionStatusAction:
+			return stubResponse(t, http.StatusOK, `<GetSubmissionStatusResponse `+tns+`/>`, zipFile(t, "status.xml", []byte(status))), nil
+		case GetNewSubmissionsStatusAction:
+			return stubResponse(t, http.StatusOK, `<GetNewSubmissionsStatusResponse `+tns+`><MoreAvailableInd>false</MoreAvailableInd></GetNewSubmissionsStatusResponse>`, zipFile(t, "status.xml", []byte(status))), nil
+		case GetSubmissionReconciliationListAction:
+			return stubResponse(t, http.StatusOK, `<GetSubmissionReconciliationListResponse `+tns+`><SubmissionIdList><Cnt>2</Cnt>`+
+				`<SubmissionId>00000020193037000001</SubmissionId><SubmissionId>00000020193037000002</SubmissionId></SubmissionIdList>`+
+				`<MoreAvailableInd>false</MoreAvailableInd></GetSubmissionReconciliationListResponse>`, nil), nil
+		case GetNewAckNotificationsAction:
+			return stubResponse(t, http.StatusOK, `<GetNewAckNotificationsResponse `+tns+`><MoreAvailableInd>false</MoreAvailableInd></GetNewAckNotificationsResponse>`, zipFile(t, "notifications.xml", []byte(notifications))), nil
+		}
+		return stubResponse(t, http.StatusInternalServerError, `<SOAP-ENV:Fault><faultcode>SOAP-ENV:Client</faultcode><faultstring>unknown action</faultstring></SOAP-ENV:Fault>`, nil), nil
+	})
+
+	ctx := context.Background()
+	client := newTestClient(ATSEndpoint, transport)
+
+	assert.Equal(t, nil, client.Login(ctx))
+
+	transmission := &efile.Irs990TransmissionFile{}
+	assert.Equal(t, nil, xml.Unmarshal(testdata(t, "irs990_transmission_file.xml"), &transmission.Soap))
+	file := &irs_990.Irs990File{TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(testdata(t, "irs990_return.xml"), &file.XmlData))
+	file.Manifest = &irs_990.IRSSubmissionManifest{}
+	assert.Equal(t, nil, xml.Unmarshal(testdata(t, "irs990_submission_manifest.xml"), file.Manifest))
+	transmission.Attachments = append(transmission.Attachments, file)
+
+	submissionReceipts, err := client.SendSubmissions(ctx, transmission)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(submissionReceipts))
+	assert.Equal(t, irs_990.SubmissionIdType("0123456789123adcdefg"), submissionReceipts[0].SubmissionId)
+
+	newAcks, more, err := client.GetNewAcks(ctx, 100)
+	assert.Equal(t, nil, err)
+	assert.True(t, more)
+	assert.Equal(t, 2, len(newAcks))
+
+	ids := []irs_990.SubmissionIdType{"00000020193037000001", "00000020193037000002"}
+	ackList, err := client.GetAcks(ctx, ids)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(ackList))
+
+	ack, err := client.GetAck(ctx, ids[1])
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Rejected", ack.AcceptanceStatusTxt)
+	_, err = client.GetAck(ctx, "00000020193037000003")
+	assert.True(t, errors.Is(err, ErrMissingResult))
+
+	record, err := client.GetSubmissionStatus(ctx, ids[0])
+	assert.Equal(t, nil, err)
+	assert.Equal(t, irs_990.AcceptedAckCreatedStatus, record.SubmissionStatusTxt)
+
+	records, more, err := client.GetNewSubmissionsStatus(ctx, 100)
+	assert.Equal(t, nil, err)
+	assert.False(t, more)
+	assert.Equal(t, 1, len(records))
+
+	reconciliation, _, err := client.GetSubmissionReconciliationList(ctx, 100)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ids, reconciliation)
+
+	ackNotifications, _, err := client.GetNewAckNotifications(ctx, 100)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(ackNotifications))
+
+	assert.Equal(t, nil, client.Logout(ctx))
+	assert.Equal(t, 0, len(client.cookies))
+
+	assert.Equal(t, []string{
+		LoginAction, SendSubmissionsAction, GetNewAcksAction, GetAcksAction, GetAckAction, GetAckAction,
+		GetSubmissionStatusAction, GetNewSubmissionsStatusAction, GetSubmissionReconciliationListAction,
+		GetNewAckNotificationsAction, LogoutAction,
+	}, actions)
+
+	_, err = client.SendSubmissions(ctx, &efile.Irs990TransmissionFile{})
+	assert.True(t, errors.Is(err, ErrMissingManifest))
+}
+
+func TestClientFault(t *testing.T) {
+	fault := transportFunc(func(req *http.Request) (*http.Response, error) {
+		return stubResponse(t, http.StatusInternalServerError, `<SOAP-ENV:Fault><faultcode>SOAP-ENV:Server</faultcode><faultstring>service unavailable</faultstring></SOAP-ENV:Fault>`, nil), nil
+	})
+	err := newTestClient(ATSEndpoint, fault).Login(context.Background())
+	assert.True(t, errors.Is(err, ErrFault))
+	assert.True(t, strings.Contains(err.Error(), "service unavailable"))
+
+	unavailable := transportFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway", Header: http.Header{}, Body: io.NopCloser(strings.NewReader("bad gateway"))}, nil
+	})
+	err = newTestClient(ATSEndpoint, unavailable).Login(context.Background())
+	assert.True(t, errors.Is(err, ErrUnexpectedStatus))
+}
+
+// newTestClient returns a test client of the test ETIN with message ids in memory
+func newTestClient(endpoint string, transport Transport) *Client {
+	client := NewClient(endpoint, utils.TestCd, "12345", "12345678", transport)
+	client.MessageIds, _ = efile.NewMessageIdGenerator(client.ETIN, irs_990.NewMemorySequenceStore())
+	return client
+}
+
+func TestMessageId(t *testing.T) {
+	client := NewClient(ProductionEndpoint, utils.ProductionCd, "12345", "12345678", nil)
+	_, err := client.header(LoginAction)
+	assert.True(t, errors.Is(err, ErrMissingMessageIds))
+
+	client.MessageIds, err = efile.NewMessageIdGenerator("12345", irs_990.NewMemorySequenceStore())
+	assert.Equal(t, nil, err)
+	header, err := client.header(LoginAction)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "P", header.TestCd)
+	assert.True(t, regexp.MustCompile(`^12345[0-9]{7}[a-z0-9]{8}$`).MatchString(string(header.MessageID)))
+	assert.Equal(t, nil, header.MessageID.Validate())
+	assert.True(t, strings.HasSuffix(string(header.MessageID), "00000000"))
+
+	// the test indicator is never defaulted
+	client.TestCd = utils.TestCd
+	header, err = client.header(LoginAction)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "T", header.TestCd)
+	client.TestCd = ""
+	_, err = client.header(LoginAction)
+	assert.True(t, errors.Is(err, ErrInvalidTestCd))
+}
+
+// unvalidatedFile zips a return without validating it, as a faulty transmitter would
+type unvalidatedFile struct {
+	*irs_990.Irs990File
+}
+
+func (r unvalidatedFile) ZipData() ([]byte, error) {
+	submission, err := xml.Marshal(&r.XmlData)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := r.Manifest.XmlData()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for name, data := range map[string][]byte{"xml/submission.xml": submission, "manifest/manifest.xml": manifest} {
+		f, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = f.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func TestServer(t *testing.T) {
+	server := NewServer()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx := context.Background()
+	client := newTestClient(ts.URL, ts.Client())
+
+	// requests need the session of Login
+	_, _, err := client.GetNewAcks(ctx, 10)
+	assert.True(t, errors.Is(err, ErrFault))
+	assert.True(t, strings.Contains(err.Error(), ErrMissingSession.Error()))
+	assert.Equal(t, nil, client.Login(ctx))
+
+	generator, err := efile.NewMessageIdGenerator("12345", irs_990.NewMemorySequenceStore())
+	assert.Equal(t, nil, err)
+	newTransmission := func(returnFile, id string) *efile.Irs990TransmissionFile {
+		file := &irs_990.Irs990File{TestCd: utils.TestCd}
+		assert.Equal(t, nil, xml.Unmarshal(testdata(t, returnFile), &file.XmlData))
+		file.Manifest = &irs_990.IRSSubmissionManifest{}
+		assert.Equal(t, nil, xml.Unmarshal(testdata(t, "irs990_submission_manifest.xml"), file.Manifest))
+		file.Manifest.SubmissionId = irs_990.SubmissionIdType(id)
+
+		transmission := &efile.Irs990TransmissionFile{Attachments: []utils.IrsReturnFile{file}}
+		assert.Equal(t, nil, transmission.BuildHeader(generator, time.Now()))
+		return transmission
+	}
+
+	accepted := irs_990.SubmissionIdType("00000020193037000001")
+	receipts, err := client.SendSubmissions(ctx, newTransmission("irs990_return.xml", string(accepted)))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(receipts))
+	assert.Equal(t, accepted, receipts[0].SubmissionId)
+
+	// an original return of the same tax period is a duplicate
+	duplicate := irs_990.SubmissionIdType("00000020193037000002")
+	_, err = client.SendSubmissions(ctx, newTransmission("irs990_return.xml", string(duplicate)))
+	assert.Equal(t, nil, err)
+
+	pending, _, err := client.GetSubmissionReconciliationList(ctx, 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []irs_990.SubmissionIdType{accepted, duplicate}, pending)
+
+	ack, err := client.GetAck(ctx, duplicate)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, irs_990.RejectedStatus, ack.AcceptanceStatusTxt)
+	assert.Equal(t, 1, ack.ValidationErrorList.ErrorCnt)
+	assert.Equal(t, string(utils.SeverityRejectAndStop), ack.ValidationErrorList.ValidationErrorGrp[0].SeverityCd)
+	assert.True(t, strings.Contains(ack.ValidationErrorList.ValidationErrorGrp[0].ErrorMessageTxt, irs_990.ErrDuplicateOriginal.Error()))
+
+	// the retrieved acknowledgement of the duplicate isn't counted as more available
+	acks, more, err := client.GetNewAcks(ctx, 1)
+	assert.Equal(t, nil, err)
+	assert.False(t, more)
+	assert.Equal(t, 1, len(acks))
+	assert.Equal(t, accepted, acks[0].SubmissionId)
+	assert.Equal(t, irs_990.AcceptedStatus, acks[0].AcceptanceStatusTxt)
+	assert.Nil(t, acks[0].ValidationErrorList)
+	assert.Equal(t, nil, acks[0].Validate())
+
+	// retrieved acknowledgements aren't new anymore
+	acks, more, err = client.GetNewAcks(ctx, 10)
+	assert.Equal(t, nil, err)
+	assert.False(t, more)
+	assert.Equal(t, 0, len(acks))
+	pending, _, err = client.GetSubmissionReconciliationList(ctx, 10)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(pending))
+
+	acks, err = client.GetAcks(ctx, []irs_990.SubmissionIdType{accepted, duplicate})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(acks))
+	_, err = client.GetAcks(ctx, []irs_990.SubmissionIdType{"00000020193037000009"})
+	assert.True(t, errors.Is(err, ErrFault))
+
+	// an invalid return is rejected by the unpacker, its return type is unknown
+	invalid := irs_990.SubmissionIdType("00000020193037000003")
+	transmission := newTransmission("irs990_invalid_return.xml", string(invalid))
+	transmission.Attachments[0] = unvalidatedFile{transmission.Attachments[0].(*irs_990.Irs990File)}
+	_, err = client.SendSubmissions(ctx, transmission)
+	assert.Equal(t, nil, err)
+	ack, err = client.GetAck(ctx, invalid)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Rejected", ack.AcceptanceStatusTxt)
+	assert.True(t, ack.ValidationErrorList.ErrorCnt > 0)
+	messages := []string{}
+	for _, grp := range ack.ValidationErrorList.ValidationErrorGrp {
+		messages = append(messages, grp.ErrorMessageTxt)
+	}
+	assert.Contains(t, messages, utils.ErrUnknownReturnType.Error()+": UNKNOWN")
+	stored, ok := server.Acknowledgement(invalid)
+	assert.True(t, ok)
+	assert.Equal(t, ack.ValidationErrorList.ErrorCnt, stored.ValidationErrorList.ErrorCnt)
+
+	record, err := client.GetSubmissionStatus(ctx, accepted)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, irs_990.AcceptedAckCreatedStatus, record.SubmissionStatusTxt)
+	record, err = client.GetSubmissionStatus(ctx, duplicate)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, irs_990.RejectedAckCreatedStatus, record.SubmissionStatusTxt)
+	records, more, err := client.GetNewSubmissionsStatus(ctx, 2)
+	assert.Equal(t, nil, err)
+	assert.True(t, more)
+	assert.Equal(t, 2, len(records))
+
+	// binary attachments are sent in the submission zip, an amended return explains its changes
+	attached := irs_990.SubmissionIdType("00000020193037000004")
+	transmission = newTransmission("irs990_return.xml", string(attached))
+	file := transmission.Attachments[0].(*irs_990.Irs990File)
+	file.XmlData.ReturnData.IRS990.AmendedReturnInd = "X"
+	binary := irs_990.BinaryAttachment{Desc: "GENERAL EXPLANATION ATTACHMENT", DocumentId: "RetDoc1038000002"}
+	assert.Equal(t, nil, file.AddAttachment(irs_990.Attachment{Name: "explanation.pdf", Data: []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n%%EOF\n")}, binary))
+	_, err = client.SendSubmissions(ctx, transmission)
+	assert.Equal(t, nil, err)
+	ack, err = client.GetAck(ctx, attached)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, ack.ValidationErrorList)
+	assert.Equal(t, "Accepted", ack.AcceptanceStatusTxt)
+
+	notifications, more, err := client.GetNewAckNotifications(ctx, 10)
+	assert.Equal(t, nil, err)
+	assert.False(t, more)
+	assert.Equal(t, 4, len(notifications))
+
+	assert.Equal(t, nil, client.Logout(ctx))
+	_, _, err = client.GetNewAcks(ctx, 10)
+	assert.True(t, errors.Is(err, ErrFault))
+}
@@ -0,0 +1,317 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package a2a
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/efile"
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+// Endpoints of the MeF A2A services
+var (
+	ATSEndpoint        = "https://la.alt.www4.irs.gov/a2a/mef"
+	ProductionEndpoint = "https://la.www4.irs.gov/a2a/mef"
+)
+
+var (
+	// ErrFault is given when MeF answers with a SOAP fault
+	ErrFault = errors.New("MeF returned a SOAP fault")
+	// ErrUnexpectedStatus is given when MeF answers with an unexpected HTTP status
+	ErrUnexpectedStatus = errors.New("MeF returned an unexpected HTTP status")
+	// ErrMissingResult is given when the response hasn't the requested record
+	ErrMissingResult = errors.New("MeF response hasn't the requested record")
+	// ErrMissingManifest is given when the transmission hasn't a transmission manifest
+	ErrMissingManifest = errors.New("transmission hasn't a transmission manifest")
+	// ErrMissingMessageIds is given when a request is sent by a client without message id generator
+	ErrMissingMessageIds = errors.New("client hasn't a message id generator")
+	// ErrInvalidTestCd is given when a request is sent by a client without a valid test indicator
+	ErrInvalidTestCd = errors.New("client test indicator must be T (test) or P (production)")
+)
+
+var (
+	defaultWSDLVersionNum = "10.3"
+	sessionKeyCd          = "Y"
+	envelopeContentType   = "text/xml; charset=UTF-8"
+)
+
+// Transport sends the HTTP requests of a client, *http.Client is a transport.
+// A transport with client certificates gives mutual TLS.
+type Transport interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is a MeF A2A web-service client, the session of Login is kept until Logout
+type Client struct {
+	// Endpoint is the base URL of the services, the operation name is appended
+	Endpoint string
+	ETIN     irs_990.ETINType
+	AppSysID string
+	// TestCd is the test indicator of the MeF header, utils.TestCd for the ATS and utils.ProductionCd for production
+	TestCd    string
+	Transport Transport
+	// WSDLVersionNum is the version of the MeF WSDL used by the client
+	WSDLVersionNum string
+	// Gzip compresses the submission attachments
+	Gzip bool
+	// MessageIds generates the message ids of the requests, requests fail without it
+	MessageIds *efile.MessageIdGenerator
+
+	mu      sync.Mutex
+	cookies map[string]*http.Cookie
+}
+
+// NewClient returns a client of the endpoint, http.DefaultClient is used without transport.
+// testCd is the test indicator of the requests, utils.TestCd for the ATS and utils.ProductionCd for production.
+func NewClient(endpoint string, testCd string, etin irs_990.ETINType, appSysID string, transport Transport) *Client {
+	if transport == nil {
+		transport = http.DefaultClient
+	}
+	return &Client{
+		Endpoint:       strings.TrimSuffix(endpoint, "/"),
+		ETIN:           etin,
+		AppSysID:       appSysID,
+		TestCd:         testCd,
+		Transport:      transport,
+		WSDLVersionNum: defaultWSDLVersionNum,
+		cookies:        map[string]*http.Cookie{},
+	}
+}
+
+// Login opens a session
+func (c *Client) Login(ctx context.Context) error {
+	var response LoginResponse
+	_, err := c.call(ctx, LoginAction, &LoginRequest{}, nil, &response)
+	return err
+}
+
+// Logout closes the session
+func (c *Client) Logout(ctx context.Context) error {
+	var response LogoutResponse
+	_, err := c.call(ctx, LogoutAction, &LogoutRequest{}, nil, &response)
+	c.mu.Lock()
+	c.cookies = map[string]*http.Cookie{}
+	c.mu.Unlock()
+	return err
+}
+
+// SendSubmissions sends the submissions of the transmission and returns the submission receipts
+func (c *Client) SendSubmissions(ctx context.Context, transmission *efile.Irs990TransmissionFile) ([]irs_990.SubmissionReceiptGrp, error) {
+	if transmission.Soap.Body == nil || transmission.Soap.Body.Manifest == nil {
+		return nil, ErrMissingManifest
+	}
+	attachment, err := transmission.SOAPAttachment()
+	if err != nil {
+		return nil, err
+	}
+	request := &SendSubmissionsRequest{SubmissionDataList: transmission.Soap.Body.Manifest.SubmissionDataList}
+	var response SendSubmissionsResponse
+	result, err := c.call(ctx, SendSubmissionsAction, request, attachment, &response)
+	if err != nil {
+		return nil, err
+	}
+	return result.SubmissionReceipts, nil
+}
+
+// GetNewAcks returns the acknowledgements that weren't retrieved yet, at most max of them
+func (c *Client) GetNewAcks(ctx context.Context, max int) ([]irs_990.Acknowledgement, bool, error) {
+	var response GetNewAcksResponse
+	result, err := c.call(ctx, GetNewAcksAction, &GetNewAcksRequest{MaxResultCnt: max}, nil, &response)
+	if err != nil {
+		return nil, false, err
+	}
+	return result.Acknowledgements, response.MoreAvailableInd, nil
+}
+
+// GetAcks returns the acknowledgements of the submissions
+func (c *Client) GetAcks(ctx context.Context, ids []irs_990.SubmissionIdType) ([]irs_990.Acknowledgement, error) {
+	request := &GetAcksRequest{SubmissionIdList: SubmissionIdList{Cnt: len(ids), SubmissionId: ids}}
+	var response GetAcksResponse
+	result, err := c.call(ctx, GetAcksAction, request, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return result.Acknowledgements, nil
+}
+
+// GetAck returns the acknowledgement of the submission
+func (c *Client) GetAck(ctx context.Context, id irs_990.SubmissionIdType) (*irs_990.Acknowledgement, error) {
+	var response GetAckResponse
+	result, err := c.call(ctx, GetAckAction, &GetAckRequest{SubmissionId: id}, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	for _, ack := range result.Acknowledgements {
+		if ack.SubmissionId == id {
+			return &ack, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: acknowledgement of %s", ErrMissingResult, id)
+}
+
+// GetSubmissionStatus returns the status record of the submission
+func (c *Client) GetSubmissionStatus(ctx context.Context, id irs_990.SubmissionIdType) (*irs_990.StatusRecordGrp, error) {
+	var response GetSubmissionStatusResponse
+	result, err := c.call(ctx, GetSubmissionStatusAction, &GetSubmissionStatusRequest{SubmissionId: id}, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range result.StatusRecords {
+		if record.SubmissionId == id {
+			return &record, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: status of %s", ErrMissingResult, id)
+}
+
+// GetNewSubmissionsStatus returns the status records that weren't retrieved yet, at most max of them
+func (c *Client) GetNewSubmissionsStatus(ctx context.Context, max int) ([]irs_990.StatusRecordGrp, bool, error) {
+	var response GetNewSubmissionsStatusResponse
+	result, err := c.call(ctx, GetNewSubmissionsStatusAction, &GetNewSubmissionsStatusRequest{MaxResultCnt: max}, nil, &response)
+	if err != nil {
+		return nil, false, err
+	}
+	return result.StatusRecords, response.MoreAvailableInd, nil
+}
+
+// GetSubmissionReconciliationList returns the ids of the submissions that have no retrieved acknowledgement, at most max of them
+func (c *Client) GetSubmissionReconciliationList(ctx context.Context, max int) ([]irs_990.SubmissionIdType, bool, error) {
+	var response GetSubmissionReconciliationListResponse
+	_, err := c.call(ctx, GetSubmissionReconciliationListAction, &GetSubmissionReconciliationListRequest{MaxResultCnt: max}, nil, &response)
+	if err != nil {
+		return nil, false, err
+	}
+	return response.SubmissionIdList.SubmissionId, response.MoreAvailableInd, nil
+}
+
+// GetNewAckNotifications returns the ack notifications that weren't retrieved yet, at most max of them
+func (c *Client) GetNewAckNotifications(ctx context.Context, max int) ([]irs_990.AckNotification, bool, error) {
+	var response GetNewAckNotificationsResponse
+	result, err := c.call(ctx, GetNewAckNotificationsAction, &GetNewAckNotificationsRequest{MaxResultCnt: max}, nil, &response)
+	if err != nil {
+		return nil, false, err
+	}
+	return result.AckNotifications, response.MoreAvailableInd, nil
+}
+
+// header returns the MeF header of a request
+func (c *Client) header(action string) (*MeFHeader, error) {
+	if c.MessageIds == nil {
+		return nil, ErrMissingMessageIds
+	}
+	if c.TestCd != utils.TestCd && c.TestCd != utils.ProductionCd {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTestCd, c.TestCd)
+	}
+	now := time.Now().UTC()
+	id, err := c.MessageIds.Next(now)
+	if err != nil {
+		return nil, err
+	}
+	header := &MeFHeader{
+		MessageID:      id,
+		Action:         action,
+		Timestamp:      irs_990.TimestampType(now),
+		ETIN:           c.ETIN,
+		SessionKeyCd:   sessionKeyCd,
+		TestCd:         c.TestCd,
+		AppSysID:       c.AppSysID,
+		WSDLVersionNum: c.WSDLVersionNum,
+	}
+	return header, nil
+}
+
+// call sends the request of an operation with the attachment and decodes the response into response.
+// It returns the parsed response with the unpacked attachment.
+func (c *Client) call(ctx context.Context, action string, request interface{}, attachment []byte, response interface{}) (*efile.Response, error) {
+	header, err := c.header(action)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := xml.Marshal(newOutEnvelope(header, request))
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := envelopeContentType
+	body := append([]byte(xml.Header), envelope...)
+	if len(attachment) > 0 {
+		contentType, body, err = utils.MultipartMessage(envelope, attachment, &utils.TransmissionOptions{Gzip: c.Gzip})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint+"/"+action, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("SOAPAction", action)
+	c.mu.Lock()
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+	c.mu.Unlock()
+
+	resp, err := c.Transport.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	for _, cookie := range resp.Cookies() {
+		c.cookies[cookie.Name] = cookie
+	}
+	c.mu.Unlock()
+
+	return parseResponse(resp, buf, response)
+}
+
+// parseResponse decodes the body of an operation response, a SOAP fault is given as ErrFault
+func parseResponse(resp *http.Response, buf []byte, response interface{}) (*efile.Response, error) {
+	result := &efile.Response{Envelope: buf}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") {
+		parsed, err := efile.ParseResponse(resp.Header.Get("Content-Type"), buf)
+		if err != nil {
+			return nil, err
+		}
+		result = parsed
+	}
+
+	var envelope inEnvelope
+	if err := xml.Unmarshal(result.Envelope, &envelope); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+		}
+		return nil, err
+	}
+	if fault := envelope.Body.Fault; fault != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrFault, fault.Faultcode, fault.Faultstring)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+	if err := xml.Unmarshal(envelope.Body.Content, response); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package a2a
+
+import (
+	"encoding/xml"
+
+	"github.com/moov-io/1120x/pkg/efile"
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+// Namespaces of the MeF A2A messages
+const (
+	MeFHeaderNamespace   = "http://www.irs.gov/a2a/mef/MeFHeader.xsd"
+	MSIServicesNamespace = "http://www.irs.gov/a2a/mef/MeFMSIServices.xsd"
+	TransmitterNamespace = "http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd"
+)
+
+// MeF A2A service operations
+const (
+	LoginAction                           = "Login"
+	LogoutAction                          = "Logout"
+	SendSubmissionsAction                 = "SendSubmissions"
+	GetNewAcksAction                      = "GetNewAcks"
+	GetAcksAction                         = "GetAcks"
+	GetAckAction                          = "GetAck"
+	GetSubmissionStatusAction             = "GetSubmissionStatus"
+	GetNewSubmissionsStatusAction         = "GetNewSubmissionsStatus"
+	GetSubmissionReconciliationListAction = "GetSubmissionReconciliationList"
+	GetNewAckNotificationsAction          = "GetNewAckNotifications"
+)
+
+// MeFHeader is the SOAP header of the MeF A2A requests and responses
+type MeFHeader struct {
+	XMLName           xml.Name              `xml:"http://www.irs.gov/a2a/mef/MeFHeader.xsd MeFHeader" json:"-"`
+	MessageID         efile.MessageIdType   `xml:"MessageID"`
+	RelatesTo         string                `xml:"RelatesTo,omitempty" json:",omitempty"`
+	Action            string                `xml:"Action"`
+	Timestamp         irs_990.TimestampType `xml:"Timestamp"`
+	ETIN              irs_990.ETINType      `xml:"ETIN"`
+	SessionKeyCd      string                `xml:"SessionKeyCd"`
+	TestCd            string                `xml:"TestCd,omitempty" json:",omitempty"`
+	AppSysID          string                `xml:"AppSysID"`
+	WSDLVersionNum    string                `xml:"WSDLVersionNum"`
+	ClientSoftwareTxt string                `xml:"ClientSoftwareTxt,omitempty" json:",omitempty"`
+}
+
+func (r MeFHeader) Validate() error {
+	return utils.Validate(&r)
+}
+
+// SubmissionIdList is a list of submission ids
+type SubmissionIdList struct {
+	Cnt          int                        `xml:"Cnt"`
+	SubmissionId []irs_990.SubmissionIdType `xml:"SubmissionId,omitempty" json:",omitempty"`
+}
+
+func (r SubmissionIdList) Validate() error {
+	return utils.Validate(&r)
+}
+
+type LoginRequest struct {
+	XMLName xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFMSIServices.xsd LoginRequest" json:"-"`
+}
+
+type LoginResponse struct {
+	XMLName   xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFMSIServices.xsd LoginResponse" json:"-"`
+	StatusTxt string   `xml:"StatusTxt"`
+}
+
+type LogoutRequest struct {
+	XMLName xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFMSIServices.xsd LogoutRequest" json:"-"`
+}
+
+type LogoutResponse struct {
+	XMLName   xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFMSIServices.xsd LogoutResponse" json:"-"`
+	StatusTxt string   `xml:"StatusTxt"`
+}
+
+// SendSubmissionsRequest is sent with the zip of the submissions as attachment
+type SendSubmissionsRequest struct {
+	XMLName            xml.Name                     `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd SendSubmissionsRequest" json:"-"`
+	SubmissionDataList efile.SubmissionDataListType `xml:"SubmissionDataList"`
+}
+
+// SendSubmissionsResponse is received with the submission receipts as attachment
+type SendSubmissionsResponse struct {
+	XMLName xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd SendSubmissionsResponse" json:"-"`
+}
+
+type GetNewAcksRequest struct {
+	XMLName      xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetNewAcksRequest" json:"-"`
+	MaxResultCnt int      `xml:"MaxResultCnt"`
+}
+
+// GetNewAcksResponse is received with the acknowledgements as attachment
+type GetNewAcksResponse struct {
+	XMLName          xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetNewAcksResponse" json:"-"`
+	MoreAvailableInd bool     `xml:"MoreAvailableInd"`
+}
+
+type GetAcksRequest struct {
+	XMLName          xml.Name         `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetAcksRequest" json:"-"`
+	SubmissionIdList SubmissionIdList `xml:"SubmissionIdList"`
+}
+
+// GetAcksResponse is received with the acknowledgements as attachment
+type GetAcksResponse struct {
+	XMLName xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetAcksResponse" json:"-"`
+}
+
+type GetAckRequest struct {
+	XMLName      xml.Name                 `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetAckRequest" json:"-"`
+	SubmissionId irs_990.SubmissionIdType `xml:"SubmissionId"`
+}
+
+// GetAckResponse is received with the acknowledgement as attachment
+type GetAckResponse struct {
+	XMLName xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetAckResponse" json:"-"`
+}
+
+type GetSubmissionStatusRequest struct {
+	XMLName      xml.Name                 `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetSubmissionStatusRequest" json:"-"`
+	SubmissionId irs_990.SubmissionIdType `xml:"SubmissionId"`
+}
+
+// GetSubmissionStatusResponse is received with the status record as attachment
+type GetSubmissionStatusResponse struct {
+	XMLName xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetSubmissionStatusResponse" json:"-"`
+}
+
+type GetNewSubmissionsStatusRequest struct {
+	XMLName      xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetNewSubmissionsStatusRequest" json:"-"`
+	MaxResultCnt int      `xml:"MaxResultCnt"`
+}
+
+// GetNewSubmissionsStatusResponse is received with the status records as attachment
+type GetNewSubmissionsStatusResponse struct {
+	XMLName          xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetNewSubmissionsStatusResponse" json:"-"`
+	MoreAvailableInd bool     `xml:"MoreAvailableInd"`
+}
+
+type GetSubmissionReconciliationListRequest struct {
+	XMLName      xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetSubmissionReconciliationListRequest" json:"-"`
+	MaxResultCnt int      `xml:"MaxResultCnt"`
+}
+
+type GetSubmissionReconciliationListResponse struct {
+	XMLName          xml.Name         `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetSubmissionReconciliationListResponse" json:"-"`
+	SubmissionIdList SubmissionIdList `xml:"SubmissionIdList"`
+	MoreAvailableInd bool             `xml:"MoreAvailableInd"`
+}
+
+type GetNewAckNotificationsRequest struct {
+	XMLName      xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetNewAckNotificationsRequest" json:"-"`
+	MaxResultCnt int      `xml:"MaxResultCnt"`
+}
+
+// GetNewAckNotificationsResponse is received with the ack notifications as attachment
+type GetNewAckNotificationsResponse struct {
+	XMLName          xml.Name `xml:"http://www.irs.gov/a2a/mef/MeFTransmitterServices.xsd GetNewAckNotificationsResponse" json:"-"`
+	MoreAvailableInd bool     `xml:"MoreAvailableInd"`
+}
+
+// outEnvelope is the SOAP envelope of a sent message, written with the SOAP-ENV prefix
+type outEnvelope struct {
+	XMLName xml.Name `xml:"SOAP-ENV:Envelope"`
+	SoapEnv string   `xml:"xmlns:SOAP-ENV,attr"`
+	Header  struct {
+		MeFHeader *MeFHeader
+	} `xml:"SOAP-ENV:Header"`
+	Body struct {
+		Content interface{}
+	} `xml:"SOAP-ENV:Body"`
+}
+
+func newOutEnvelope(header *MeFHeader, content interface{}) *outEnvelope {
+	envelope := &outEnvelope{SoapEnv: efile.SoapEnvNamespace}
+	envelope.Header.MeFHeader = header
+	envelope.Body.Content = content
+	return envelope
+}
+
+// inEnvelope is the SOAP envelope of a received message, the body content is decoded by the operation
+type inEnvelope struct {
+	XMLName xml.Name `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+	Header  struct {
+		MeFHeader *MeFHeader `xml:"http://www.irs.gov/a2a/mef/MeFHeader.xsd MeFHeader"`
+	} `xml:"http://schemas.xmlsoap.org/soap/envelope/ Header"`
+	Body struct {
+		Fault   *efile.Fault `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault"`
+		Content []byte       `xml:",innerxml"`
+	} `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
+}
@@ -0,0 +1,482 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package a2a
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/efile"
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	// ErrMissingSession is given when a request is sent without the session of Login
+	ErrMissingSession = errors.New("session isn't established")
+	// ErrUnknownAction is given when the action isn't a MeF A2A service operation
+	ErrUnknownAction = errors.New("unknown action")
+	// ErrUnknownSubmission is given when the server hasn't received the submission
+	ErrUnknownSubmission = errors.New("unknown submission id")
+	// ErrMissingMeFHeader is given when a request hasn't the MeF header
+	ErrMissingMeFHeader = errors.New("request hasn't a MeFHeader")
+)
+
+var (
+	sessionCookie       = "JSESSIONID"
+	clientFaultCode     = "SOAP-ENV:Client"
+	irsGovernmentCd     = "IRS"
+	eoCategoryCd        = "EO"
+	returnDocumentId    = irs_990.IdType("IRS990")
+	defaultMaxResultCnt = 100
+)
+
+// Server is an in-process fake of the MeF A2A services, usable with httptest.
+//
+// Received submissions are unpacked and validated against the schema and the business rules of
+// the repository, and their acknowledgements are queued for the Get operations.
+type Server struct {
+	// Now returns the time of the server, time.Now is used when nil
+	Now func() time.Time
+
+	mu            sync.Mutex
+	messageIds    *irs_990.MemorySequenceStore
+	sessions      map[string]bool
+	store         *irs_990.MemorySubmissionStore
+	acks          map[irs_990.SubmissionIdType]irs_990.Acknowledgement
+	status        map[irs_990.SubmissionIdType]irs_990.StatusRecordGrp
+	submissions   []irs_990.SubmissionIdType
+	retrieved     map[irs_990.SubmissionIdType]bool
+	newAcks       []irs_990.SubmissionIdType
+	newStatus     []irs_990.SubmissionIdType
+	notifications []irs_990.AckNotification
+}
+
+// NewServer returns a server without submissions
+func NewServer() *Server {
+	return &Server{
+		messageIds: irs_990.NewMemorySequenceStore(),
+		sessions:   map[string]bool{},
+		store:      irs_990.NewMemorySubmissionStore(),
+		acks:       map[irs_990.SubmissionIdType]irs_990.Acknowledgement{},
+		status:     map[irs_990.SubmissionIdType]irs_990.StatusRecordGrp{},
+		retrieved:  map[irs_990.SubmissionIdType]bool{},
+	}
+}
+
+// Acknowledgement returns the queued acknowledgement of a submission
+func (s *Server) Acknowledgement(id irs_990.SubmissionIdType) (irs_990.Acknowledgement, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ack, ok := s.acks[id]
+	return ack, ok
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// ServeHTTP answers a MeF A2A request, errors are answered with a SOAP fault
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeFault(w, err)
+		return
+	}
+
+	request := &efile.Response{Envelope: body, Files: map[string][]byte{}}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if request, err = efile.ParseResponse(r.Header.Get("Content-Type"), body); err != nil {
+			writeFault(w, err)
+			return
+		}
+	}
+	var envelope inEnvelope
+	if err = xml.Unmarshal(request.Envelope, &envelope); err != nil {
+		writeFault(w, err)
+		return
+	}
+	header := envelope.Header.MeFHeader
+	if header == nil {
+		writeFault(w, ErrMissingMeFHeader)
+		return
+	}
+
+	session := ""
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		session = cookie.Value
+	}
+	if header.Action != LoginAction {
+		s.mu.Lock()
+		established := s.sessions[session]
+		s.mu.Unlock()
+		if !established {
+			writeFault(w, ErrMissingSession)
+			return
+		}
+	}
+
+	content, attachment, err := s.serve(w, header, session, envelope.Body.Content, request)
+	if err != nil {
+		writeFault(w, err)
+		return
+	}
+
+	now := s.now().UTC()
+	generator, err := efile.NewMessageIdGenerator(header.ETIN, s.messageIds)
+	if err != nil {
+		writeFault(w, err)
+		return
+	}
+	id, err := generator.Next(now)
+	if err != nil {
+		writeFault(w, err)
+		return
+	}
+	replyHeader := *header
+	replyHeader.MessageID = id
+	replyHeader.RelatesTo = string(header.MessageID)
+	replyHeader.Timestamp = irs_990.TimestampType(now)
+	buf, err := xml.Marshal(newOutEnvelope(&replyHeader, content))
+	if err != nil {
+		writeFault(w, err)
+		return
+	}
+
+	contentType := envelopeContentType
+	buf = append([]byte(xml.Header), buf...)
+	if len(attachment) > 0 {
+		if contentType, buf, err = utils.MultipartMessage(buf, attachment, nil); err != nil {
+			writeFault(w, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf)
+}
+
+// serve runs the operation of the request, it returns the body content and the attachment of the response
+func (s *Server) serve(w http.ResponseWriter, header *MeFHeader, session string, content []byte, request *efile.Response) (interface{}, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch header.Action {
+	case LoginAction:
+		var req LoginRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		key := make([]byte, 16)
+		if _, err := rand.Read(key); err != nil {
+			return nil, nil, err
+		}
+		value := hex.EncodeToString(key)
+		s.sessions[value] = true
+		http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: value, Path: "/"})
+		return &LoginResponse{StatusTxt: "Success"}, nil, nil
+
+	case LogoutAction:
+		var req LogoutRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		delete(s.sessions, session)
+		return &LogoutResponse{StatusTxt: "Success"}, nil, nil
+
+	case SendSubmissionsAction:
+		var req SendSubmissionsRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		receipts := irs_990.SubmissionReceiptList{}
+		for _, data := range req.SubmissionDataList.SubmissionData {
+			s.receive(data, header.TestCd, request.Attachment)
+			receipts.Cnt++
+			receipts.SubmissionReceiptGrp = append(receipts.SubmissionReceiptGrp, irs_990.SubmissionReceiptGrp{
+				SubmissionId:         data.SubmissionId,
+				SubmissionReceivedTs: irs_990.TimestampType(s.now()),
+			})
+		}
+		attachment, err := zipXml("SubmissionReceiptList", receipts)
+		return &SendSubmissionsResponse{}, attachment, err
+
+	case GetNewAcksAction:
+		var req GetNewAcksRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		var ids []irs_990.SubmissionIdType
+		ids, s.newAcks = takeIds(s.newAcks, s.retrieved, req.MaxResultCnt)
+		attachment, err := s.ackAttachment(ids)
+		return &GetNewAcksResponse{MoreAvailableInd: countIds(s.newAcks, s.retrieved) > 0}, attachment, err
+
+	case GetAcksAction:
+		var req GetAcksRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		attachment, err := s.ackAttachment(req.SubmissionIdList.SubmissionId)
+		return &GetAcksResponse{}, attachment, err
+
+	case GetAckAction:
+		var req GetAckRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		attachment, err := s.ackAttachment([]irs_990.SubmissionIdType{req.SubmissionId})
+		return &GetAckResponse{}, attachment, err
+
+	case GetSubmissionStatusAction:
+		var req GetSubmissionStatusRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		attachment, err := s.statusAttachment([]irs_990.SubmissionIdType{req.SubmissionId})
+		return &GetSubmissionStatusResponse{}, attachment, err
+
+	case GetNewSubmissionsStatusAction:
+		var req GetNewSubmissionsStatusRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		var ids []irs_990.SubmissionIdType
+		ids, s.newStatus = takeIds(s.newStatus, nil, req.MaxResultCnt)
+		attachment, err := s.statusAttachment(ids)
+		return &GetNewSubmissionsStatusResponse{MoreAvailableInd: len(s.newStatus) > 0}, attachment, err
+
+	case GetSubmissionReconciliationListAction:
+		var req GetSubmissionReconciliationListRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		var pending []irs_990.SubmissionIdType
+		for _, id := range s.submissions {
+			if !s.retrieved[id] {
+				pending = append(pending, id)
+			}
+		}
+		ids, rest := takeIds(pending, nil, req.MaxResultCnt)
+		return &GetSubmissionReconciliationListResponse{
+			SubmissionIdList: SubmissionIdList{Cnt: len(ids), SubmissionId: ids},
+			MoreAvailableInd: len(rest) > 0,
+		}, nil, nil
+
+	case GetNewAckNotificationsAction:
+		var req GetNewAckNotificationsRequest
+		if err := xml.Unmarshal(content, &req); err != nil {
+			return nil, nil, err
+		}
+		count := maxResultCnt(req.MaxResultCnt)
+		if count > len(s.notifications) {
+			count = len(s.notifications)
+		}
+		list := irs_990.AckNotificationList{Cnt: count, AckNotification: s.notifications[:count]}
+		s.notifications = s.notifications[count:]
+		attachment, err := zipXml("AckNotificationList", list)
+		return &GetNewAckNotificationsResponse{MoreAvailableInd: len(s.notifications) > 0}, attachment, err
+	}
+
+	return nil, nil, fmt.Errorf("%w: %s", ErrUnknownAction, header.Action)
+}
+
+// receive validates a submission of the attachment and queues its acknowledgement
+func (s *Server) receive(data efile.SubmissionDataType, testCd string, attachment []byte) {
+	id := data.SubmissionId
+	now := s.now()
+	postmark := data.ElectronicPostmarkTs
+	ack := irs_990.Acknowledgement{
+		SubmissionId:                id,
+		ExtndGovernmentCd:           irs_990.ExtndGovernmentCdType(irsGovernmentCd),
+		ExtndSubmissionCategoryCd:   irs_990.ExtndSubmissionCategoryCdType(eoCategoryCd),
+		StatusDt:                    irs_990.DateType(now),
+		ElectronicPostmarkTs:        &postmark,
+		SubmissionValidationCompInd: true,
+	}
+
+	var result utils.ValidationResult
+	file, problems, err := unpackSubmission(attachment, id, testCd)
+	if err != nil {
+		result.Add(utils.SeverityRejectAndStop, err)
+	} else {
+		result = file.ValidationResult()
+		for _, problem := range problems {
+			result.Add(utils.SeverityRejectAndStop, problem)
+		}
+		result.Add(utils.SeverityRejectAndStop, irs_990.CheckDuplicates(file, s.store))
+
+		manifest := file.Manifest
+		ack.EFIN = manifest.EFIN
+		ack.SubmissionTyp = irs_990.SubmissionTyp(manifest.FederalSubmissionTypeCd)
+		ack.TIN = manifest.TIN
+		ack.TaxYr = manifest.TaxYr
+		ack.TaxPeriodEndDt = manifest.TaxPeriodEndDt
+	}
+
+	ack.ValidationErrorList, ack.ValidationAlertList = irs_990.ValidationLists(result, returnDocumentId)
+	ack.ContainedAlertsInd = ack.ValidationAlertList != nil
+	ack.AcceptanceStatusTxt = irs_990.AcceptedStatus
+	statusTxt := irs_990.AcceptedAckCreatedStatus
+	if result.Err() != nil {
+		ack.AcceptanceStatusTxt = irs_990.RejectedStatus
+		statusTxt = irs_990.RejectedAckCreatedStatus
+	}
+
+	if file != nil {
+		record := irs_990.NewSubmissionRecord(file)
+		record.SubmissionId = id
+		record.Rejected = ack.AcceptanceStatusTxt == irs_990.RejectedStatus
+		s.store.Add(record)
+	}
+
+	if _, ok := s.acks[id]; !ok {
+		s.submissions = append(s.submissions, id)
+	}
+	s.acks[id] = ack
+	s.status[id] = irs_990.StatusRecordGrp{
+		SubmissionId:                  id,
+		SubmissionStatusTxt:           statusTxt,
+		SubmsnStatusAcknowledgementDt: irs_990.DateType(now),
+	}
+	delete(s.retrieved, id)
+	s.newAcks = append(s.newAcks, id)
+	s.newStatus = append(s.newStatus, id)
+	s.notifications = append(s.notifications, irs_990.AckNotification{SubmissionId: id, Ts: irs_990.TimestampType(now)})
+}
+
+// ackAttachment returns the acknowledgements of the submissions, the acknowledgements are retrieved
+func (s *Server) ackAttachment(ids []irs_990.SubmissionIdType) ([]byte, error) {
+	list := irs_990.AcknowledgementList{}
+	for _, id := range ids {
+		ack, ok := s.acks[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSubmission, id)
+		}
+		s.retrieved[id] = true
+		list.Cnt++
+		list.Acknowledgement = append(list.Acknowledgement, ack)
+	}
+	return zipXml("AcknowledgementList", list)
+}
+
+// statusAttachment returns the status records of the submissions
+func (s *Server) statusAttachment(ids []irs_990.SubmissionIdType) ([]byte, error) {
+	list := irs_990.StatusRecordList{}
+	for _, id := range ids {
+		record, ok := s.status[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSubmission, id)
+		}
+		list.Cnt++
+		list.StatusRecordGrp = append(list.StatusRecordGrp, record)
+	}
+	return zipXml("StatusRecordList", list)
+}
+
+// unpackSubmission reads the submission zip of the submission id from the attachment with the
+// structural problems of the zip, a submission without manifest can't be acknowledged
+func unpackSubmission(attachment []byte, id irs_990.SubmissionIdType, testCd string) (*irs_990.Irs990File, []error, error) {
+	buf, err := efile.SubmissionZip(attachment, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	unpacked, err := efile.UnpackSubmission(buf, testCd)
+	if err != nil {
+		return nil, nil, err
+	}
+	file := unpacked.Irs990File()
+	if file == nil {
+		return nil, nil, fmt.Errorf("%w: %s", utils.ErrUnknownReturnType, unpacked.ReturnTypeCd)
+	}
+	if file.Manifest == nil {
+		return nil, nil, fmt.Errorf("%w: %s", efile.ErrMissingManifestXml, id)
+	}
+	return file, unpacked.Problems, nil
+}
+
+// takeIds takes at most max ids from the queue, the skipped ids are dropped
+func takeIds(queue []irs_990.SubmissionIdType, skipped map[irs_990.SubmissionIdType]bool, max int) ([]irs_990.SubmissionIdType, []irs_990.SubmissionIdType) {
+	max = maxResultCnt(max)
+	var ids []irs_990.SubmissionIdType
+	for len(queue) > 0 && len(ids) < max {
+		id := queue[0]
+		queue = queue[1:]
+		if !skipped[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, queue
+}
+
+// countIds returns the number of ids of the queue that aren't skipped
+func countIds(queue []irs_990.SubmissionIdType, skipped map[irs_990.SubmissionIdType]bool) int {
+	count := 0
+	for _, id := range queue {
+		if !skipped[id] {
+			count++
+		}
+	}
+	return count
+}
+
+func maxResultCnt(max int) int {
+	if max <= 0 || max > defaultMaxResultCnt {
+		return defaultMaxResultCnt
+	}
+	return max
+}
+
+// zipXml returns a zip of the xml document of the value
+func zipXml(name string, value interface{}) ([]byte, error) {
+	doc := new(bytes.Buffer)
+	doc.WriteString(xml.Header)
+	start := xml.StartElement{Name: xml.Name{Space: efile.EfileNamespace, Local: name}}
+	if err := xml.NewEncoder(doc).EncodeElement(value, start); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	f, err := writer.Create(name + ".xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.Write(doc.Bytes()); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// soapFault is a fault written with the SOAP-ENV prefix
+type soapFault struct {
+	XMLName xml.Name `xml:"SOAP-ENV:Fault"`
+	efile.Fault
+}
+
+func writeFault(w http.ResponseWriter, err error) {
+	envelope := newOutEnvelope(nil, &soapFault{Fault: efile.Fault{Faultcode: clientFaultCode, Faultstring: err.Error()}})
+	buf, _ := xml.Marshal(envelope)
+	w.Header().Set("Content-Type", envelopeContentType)
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write(append([]byte(xml.Header), buf...))
+}
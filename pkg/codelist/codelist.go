@@ -0,0 +1,237 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package codelist
+
+import (
+	"embed"
+	"encoding/xml"
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// IRS code lists, one directory per tax year in which the enumerations changed
+//
+//go:embed data
+var dataFS embed.FS
+
+var (
+	// ErrEmptyCodeList is given when hasn't any code list data
+	ErrEmptyCodeList = errors.New("hasn't any code list data")
+)
+
+var (
+	dataDir       = "data"
+	countriesFile = "CountriesList.xml"
+	statesFile    = "StatesList.xml"
+)
+
+// Categories of StateType codes
+var (
+	StateCategory                 = "State"
+	DistrictCategory              = "District"
+	TerritoryCategory             = "Territory"
+	FreelyAssociatedStateCategory = "FreelyAssociatedState"
+	MilitaryCategory              = "Military"
+)
+
+// Country is an entry of the IRS country code list
+type Country struct {
+	Code     string `xml:"id,attr"`
+	Name     string `xml:",chardata"`
+	Obsolete bool   `xml:"obsolete,attr,omitempty" json:",omitempty"`
+}
+
+// State is an entry of the IRS state, territory and military code list
+type State struct {
+	Code     string `xml:"id,attr"`
+	Name     string `xml:",chardata"`
+	Category string `xml:"category,attr"`
+}
+
+// CodeList holds the country and state enumerations of a tax year
+type CodeList struct {
+	Year      int
+	Countries []Country
+	States    []State
+
+	countries map[string]Country
+	states    map[string]State
+}
+
+// Country returns the country entry of code, including obsolete codes
+func (c *CodeList) Country(code string) (Country, bool) {
+	country, ok := c.countries[code]
+	return country, ok
+}
+
+// State returns the state entry of code
+func (c *CodeList) State(code string) (State, bool) {
+	state, ok := c.states[code]
+	return state, ok
+}
+
+// IsCountry reports whether code is a current (non obsolete) country code
+func (c *CodeList) IsCountry(code string) bool {
+	country, ok := c.countries[code]
+	return ok && !country.Obsolete
+}
+
+// IsState reports whether code is a valid state, territory or military code
+func (c *CodeList) IsState(code string) bool {
+	_, ok := c.states[code]
+	return ok
+}
+
+// ActiveCountries returns the current country codes in list order
+func (c *CodeList) ActiveCountries() []Country {
+	var countries []Country
+	for _, country := range c.Countries {
+		if !country.Obsolete {
+			countries = append(countries, country)
+		}
+	}
+	return countries
+}
+
+// StatesByCategory returns the state codes of a category in list order
+func (c *CodeList) StatesByCategory(category string) []State {
+	var states []State
+	for _, state := range c.States {
+		if state.Category == category {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+var codeLists = mustLoad()
+
+// Years returns tax years that have a code list, in ascending order
+func Years() []int {
+	years := make([]int, 0, len(codeLists))
+	for _, list := range codeLists {
+		years = append(years, list.Year)
+	}
+	return years
+}
+
+// ForYear returns the code list in effect for a tax year.
+// Years before the first list use the first list.
+func ForYear(year int) *CodeList {
+	selected := codeLists[0]
+	for _, list := range codeLists {
+		if list.Year > year {
+			break
+		}
+		selected = list
+	}
+	return selected
+}
+
+// Latest returns the most recent code list
+func Latest() *CodeList {
+	return codeLists[len(codeLists)-1]
+}
+
+// IsCountry reports whether code is a current country code of the list of any tax year.
+// Use ForYear(year).IsCountry to check a code against the list of a tax year.
+func IsCountry(code string) bool {
+	for _, list := range codeLists {
+		if list.IsCountry(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsState reports whether code is a state code of the list of any tax year.
+// Use ForYear(year).IsState to check a code against the list of a tax year.
+func IsState(code string) bool {
+	for _, list := range codeLists {
+		if list.IsState(code) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustLoad() []*CodeList {
+	lists, err := load()
+	if err != nil {
+		panic("codelist: " + err.Error())
+	}
+	return lists
+}
+
+func load() ([]*CodeList, error) {
+	entries, err := dataFS.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []*CodeList
+	for _, entry := range entries {
+		year, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		list, err := loadYear(year, path.Join(dataDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	if len(lists) == 0 {
+		return nil, ErrEmptyCodeList
+	}
+
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Year < lists[j].Year })
+	return lists, nil
+}
+
+func loadYear(year int, dir string) (*CodeList, error) {
+	var countries struct {
+		Country []Country `xml:"country"`
+	}
+	var states struct {
+		State []State `xml:"state"`
+	}
+
+	buf, err := dataFS.ReadFile(path.Join(dir, countriesFile))
+	if err != nil {
+		return nil, err
+	}
+	if err = xml.Unmarshal(buf, &countries); err != nil {
+		return nil, err
+	}
+	buf, err = dataFS.ReadFile(path.Join(dir, statesFile))
+	if err != nil {
+		return nil, err
+	}
+	if err = xml.Unmarshal(buf, &states); err != nil {
+		return nil, err
+	}
+
+	list := &CodeList{
+		Year:      year,
+		Countries: countries.Country,
+		States:    states.State,
+		countries: make(map[string]Country, len(countries.Country)),
+		states:    make(map[string]State, len(states.State)),
+	}
+	for _, country := range list.Countries {
+		// a code can be listed again as an obsolete entry, the current entry wins
+		if previous, ok := list.countries[country.Code]; ok && !previous.Obsolete {
+			continue
+		}
+		list.countries[country.Code] = country
+	}
+	for _, state := range list.States {
+		list.states[state.Code] = state
+	}
+	return list, nil
+}
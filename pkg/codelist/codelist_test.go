@@ -0,0 +1,82 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package codelist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeListYears(t *testing.T) {
+	years := Years()
+	assert.Equal(t, []int{2015, 2019}, years)
+
+	assert.Equal(t, 2015, ForYear(2010).Year)
+	assert.Equal(t, 2015, ForYear(2015).Year)
+	assert.Equal(t, 2015, ForYear(2018).Year)
+	assert.Equal(t, 2019, ForYear(2019).Year)
+	assert.Equal(t, 2019, ForYear(2030).Year)
+	assert.Equal(t, 2019, Latest().Year)
+}
+
+func TestCountries(t *testing.T) {
+	list := ForYear(2015)
+	assert.Equal(t, 258, len(list.Countries))
+	assert.Equal(t, 258, len(list.ActiveCountries()))
+	assert.True(t, list.IsCountry("UC"))
+	assert.False(t, list.IsCountry("GP"))
+
+	list = Latest()
+	assert.True(t, list.IsCountry("GP"))
+	assert.True(t, list.IsCountry("UC"))
+	assert.False(t, list.IsCountry("US"))
+	assert.False(t, list.IsCountry(""))
+
+	country, ok := list.Country("XW")
+	assert.True(t, ok)
+	assert.True(t, country.Obsolete)
+	assert.Equal(t, "Wales", country.Name)
+	assert.Less(t, len(list.ActiveCountries()), len(list.Countries))
+
+	country, ok = list.Country("UC")
+	assert.True(t, ok)
+	assert.Equal(t, "Curacao", country.Name)
+
+	country, ok = list.Country("CA")
+	assert.True(t, ok)
+	assert.Equal(t, "Canada", country.Name)
+
+	assert.True(t, IsCountry("CA"))
+	assert.False(t, IsCountry("ZZ"))
+	// codes of any year are known without a tax year
+	assert.True(t, IsCountry("GP"))
+	assert.False(t, ForYear(2015).IsCountry("GP"))
+	assert.False(t, IsCountry("XW"))
+}
+
+func TestStates(t *testing.T) {
+	list := Latest()
+	assert.Equal(t, 62, len(list.States))
+
+	state, ok := list.State("PR")
+	assert.True(t, ok)
+	assert.Equal(t, "Puerto Rico", state.Name)
+	assert.Equal(t, TerritoryCategory, state.Category)
+
+	_, ok = list.State("ZZ")
+	assert.False(t, ok)
+
+	assert.Equal(t, 50, len(list.StatesByCategory(StateCategory)))
+	assert.Equal(t, 1, len(list.StatesByCategory(DistrictCategory)))
+	assert.Equal(t, 5, len(list.StatesByCategory(TerritoryCategory)))
+	assert.Equal(t, 3, len(list.StatesByCategory(FreelyAssociatedStateCategory)))
+	assert.Equal(t, 3, len(list.StatesByCategory(MilitaryCategory)))
+
+	assert.True(t, IsState("CA"))
+	assert.True(t, IsState("AE"))
+	assert.False(t, IsState("ca"))
+	assert.False(t, IsState(""))
+}
@@ -6,35 +6,13 @@ package efile
 
 import (
 	"bytes"
-	"encoding/xml"
-)
 
-type FaultDetail []string
+	"github.com/moov-io/1120x/pkg/utils"
+)
 
-func (r FaultDetail) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	var output struct {
-		ArrayType string   `xml:"http://schemas.xmlsoap.org/wsdl/ arrayType,attr"`
-		Items     []string `xml:"item"`
-	}
-	output.Items = []string(r)
-	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: "", Local: "xmlns:ns1"}, Value: "http://www.w3.org/2001/XMLSchema"})
-	output.ArrayType = "ns1:anyType[]"
-	return e.EncodeElement(&output, start)
-}
-func (r *FaultDetail) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
-	var tok xml.Token
-	for tok, err = d.Token(); err == nil; tok, err = d.Token() {
-		if tok, ok := tok.(xml.StartElement); ok {
-			var item string
-			if err = d.DecodeElement(&item, &tok); err == nil {
-				*r = append(*r, item)
-			}
-		}
-		if _, ok := tok.(xml.EndElement); ok {
-			break
-		}
-	}
-	return err
+// FaultDetail holds the application specific error information of a fault
+type FaultDetail struct {
+	Content string `xml:",innerxml"`
 }
 
 type EncodingStyle []string
@@ -55,8 +33,13 @@ func (r *EncodingStyle) UnmarshalText(text []byte) error {
 
 // Fault reporting structure
 type Fault struct {
-	Faultcode   xml.Name     `xml:"faultcode"`
+	// Faultcode is the qualified name of the fault code, such as SOAP-ENV:Server
+	Faultcode   string       `xml:"faultcode"`
 	Faultstring string       `xml:"faultstring"`
 	Faultactor  string       `xml:"faultactor,omitempty" json:",omitempty"`
 	Detail      *FaultDetail `xml:"detail,omitempty" json:",omitempty"`
 }
+
+func (r Fault) Validate() error {
+	return utils.Validate(&r)
+}
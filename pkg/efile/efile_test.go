@@ -5,13 +5,24 @@
 package efile
 
 import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,9 +35,9 @@ func TestIrs990TransmissionFile(t *testing.T) {
 
 	err = xml.Unmarshal(InputXML, &transmission.Soap)
 	assert.Equal(t, nil, err)
-
-	err = transmission.Soap.Init()
-	assert.Equal(t, nil, err)
+	assert.NotNil(t, transmission.Soap.Header.Transmission)
+	assert.Equal(t, MessageIdType("012345678912abcdefgh"), transmission.Soap.Header.Transmission.MessageId)
+	assert.Equal(t, 1, transmission.Soap.Body.Manifest.SubmissionDataList.Cnt)
 
 	// 2. struct to xml buf
 	xmlOrgBuf, err := xml.MarshalIndent(transmission, "", "\t")
@@ -53,8 +64,16 @@ func TestIrs990TransmissionFile(t *testing.T) {
 	assert.Equal(t, xmlOrgBuf, xmlBuf)
 
 	// 7. check functions
-	_, err = newTransmission.SOAPEnvelope()
+	envelope, err := newTransmission.SOAPEnvelope()
+	assert.Equal(t, nil, err)
+	assert.True(t, bytes.HasPrefix(envelope, []byte(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/">`)))
+	assert.False(t, bytes.Contains(envelope, []byte("&lt;")))
+
+	soap := Envelope{}
+	err = xml.Unmarshal(envelope, &soap)
 	assert.Equal(t, nil, err)
+	assert.Equal(t, newTransmission.Soap.Header, soap.Header)
+	assert.Equal(t, newTransmission.Soap.Body, soap.Body)
 
 	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
 	assert.Equal(t, nil, err)
@@ -62,7 +81,7 @@ func TestIrs990TransmissionFile(t *testing.T) {
 	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
 	assert.Equal(t, nil, err)
 
-	file := &irs_990.Irs990File{}
+	file := &irs_990.Irs990File{TestCd: utils.TestCd}
 
 	err = xml.Unmarshal(returnBuf, &file.XmlData)
 	assert.Equal(t, nil, err)
@@ -77,7 +96,56 @@ func TestIrs990TransmissionFile(t *testing.T) {
 	assert.Equal(t, nil, err)
 
 	version := newTransmission.Version()
-	assert.Equal(t, "2015v2.0", version)
+	assert.Equal(t, "2019v5.0", version)
+
+	// 8. check transmission message
+	for _, compress := range []bool{false, true} {
+		message, err := newTransmission.TransmissionMessage(&utils.TransmissionOptions{Boundary: "TestBoundary", Gzip: compress})
+		assert.Equal(t, nil, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(message))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "multipart/related", mediaType)
+		assert.Equal(t, "TestBoundary", params["boundary"])
+		assert.Equal(t, "text/xml", params["type"])
+		assert.Equal(t, "<Envelope>", params["start"])
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		part, err := reader.NextPart()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, utils.EnvelopeLocation, part.Header.Get("Content-Location"))
+		assert.Equal(t, params["start"], part.Header.Get("Content-ID"))
+		assert.Equal(t, "8bit", part.Header.Get("Content-Transfer-Encoding"))
+		envelope, err := io.ReadAll(part)
+		assert.Equal(t, nil, err)
+		assert.True(t, bytes.HasPrefix(envelope, []byte(xml.Header)))
+
+		part, err = reader.NextPart()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, utils.AttachmentLocation, part.Header.Get("Content-Location"))
+		assert.Equal(t, "binary", part.Header.Get("Content-Transfer-Encoding"))
+		attachment, err := io.ReadAll(part)
+		assert.Equal(t, nil, err)
+		if compress {
+			assert.Equal(t, "gzip", part.Header.Get("Content-Encoding"))
+			gz, err := gzip.NewReader(bytes.NewReader(attachment))
+			assert.Equal(t, nil, err)
+			attachment, err = io.ReadAll(gz)
+			assert.Equal(t, nil, err)
+		}
+		_, err = zip.NewReader(bytes.NewReader(attachment), int64(len(attachment)))
+		assert.Equal(t, nil, err)
+
+		_, err = reader.NextPart()
+		assert.Equal(t, io.EOF, err)
+	}
+
+	_, err = newTransmission.TransmissionMessage(&utils.TransmissionOptions{Boundary: "bad boundary "})
+	assert.NotNil(t, err)
 }
 
 func TestUnusedStructs(t *testing.T) {
@@ -91,8 +159,6 @@ func TestUnusedStructs(t *testing.T) {
 	assert.Equal(t, nil, err)
 
 	style := &EncodingStyle{"test1", "test2"}
-	err = xml.Unmarshal(InputXML, fault)
-	assert.Equal(t, nil, err)
 	_, err = xml.Marshal(style)
 	assert.Equal(t, nil, err)
 	_, err = style.MarshalText()
@@ -126,3 +192,363 @@ func TestUnusedStructs(t *testing.T) {
 	err = mani.Validate()
 	assert.NotNil(t, err)
 }
+
+func TestParseResponse(t *testing.T) {
+	envelope, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_transmission_file.xml"))
+	assert.Equal(t, nil, err)
+	acks, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_acknowledgement_list.xml"))
+	assert.Equal(t, nil, err)
+
+	receipts := `<SubmissionReceiptList xmlns="http://www.irs.gov/efile"><Cnt>1</Cnt><SubmissionReceiptGrp>` +
+		`<SubmissionId>00000020193037000001</SubmissionId><SubmissionReceivedTs>2019-11-01T09:30:47-05:00</SubmissionReceivedTs>` +
+		`</SubmissionReceiptGrp></SubmissionReceiptList>`
+	status := `<StatusRecordGrp xmlns="http://www.irs.gov/efile"><SubmissionId>00000020193037000001</SubmissionId>` +
+		`<SubmissionStatusTxt>Accepted Acknowledgement Created</SubmissionStatusTxt><SubmsnStatusAcknowledgementDt>2019-11-02</SubmsnStatusAcknowledgementDt>` +
+		`</StatusRecordGrp>`
+
+	zipFiles := func(files map[string][]byte) []byte {
+		buf := new(bytes.Buffer)
+		writer := zip.NewWriter(buf)
+		for name, data := range files {
+			f, err := writer.Create(name)
+			assert.Equal(t, nil, err)
+			_, err = f.Write(data)
+			assert.Equal(t, nil, err)
+		}
+		assert.Equal(t, nil, writer.Close())
+		return buf.Bytes()
+	}
+	attachment := zipFiles(map[string][]byte{
+		"acks.xml":     acks,
+		"receipts.xml": []byte(receipts),
+		"status.zip":   zipFiles(map[string][]byte{"status.xml": []byte(status)}),
+	})
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":     {"text/xml; charset=UTF-8"},
+		"Content-Location": {utils.EnvelopeLocation},
+	})
+	assert.Equal(t, nil, err)
+	_, err = part.Write(envelope)
+	assert.Equal(t, nil, err)
+	part, err = writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":     {"application/octet-stream"},
+		"Content-Location": {utils.AttachmentLocation},
+		"Content-Encoding": {"gzip"},
+	})
+	assert.Equal(t, nil, err)
+	gz := gzip.NewWriter(part)
+	_, err = gz.Write(attachment)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, gz.Close())
+	assert.Equal(t, nil, writer.Close())
+
+	response, err := ParseResponse(utils.TransmissionContentType(writer.Boundary()), body.Bytes())
+	assert.Equal(t, nil, err)
+
+	// the Content-ID of the start parameter identifies the envelope before its location
+	header := textproto.MIMEHeader{}
+	header.Set("Content-ID", utils.EnvelopeContentID)
+	header.Set("Content-Location", utils.AttachmentLocation)
+	assert.True(t, isEnvelopePart(header, utils.EnvelopeContentID))
+	header.Set("Content-ID", "<Attachment>")
+	header.Set("Content-Location", utils.EnvelopeLocation)
+	assert.False(t, isEnvelopePart(header, utils.EnvelopeContentID))
+	assert.Equal(t, envelope, response.Envelope)
+	assert.Equal(t, attachment, response.Attachment)
+	assert.Equal(t, 3, len(response.Files))
+	assert.NotNil(t, response.Files["status.zip/status.xml"])
+	assert.NotNil(t, response.Soap.Body)
+
+	assert.Equal(t, 2, len(response.Acknowledgements))
+	assert.Equal(t, "Accepted", response.Acknowledgements[0].AcceptanceStatusTxt)
+	rejected := response.Acknowledgements[1]
+	assert.Equal(t, irs_990.SubmissionIdType("00000020193037000002"), rejected.SubmissionId)
+	assert.Equal(t, 1, len(rejected.ValidationErrorList.ValidationErrorGrp))
+	assert.Equal(t, "R0000-902-01", rejected.ValidationErrorList.ValidationErrorGrp[0].RuleNum)
+	assert.Equal(t, 1, len(rejected.ValidationAlertList.ValidationAlertGrp))
+	for _, ack := range response.Acknowledgements {
+		assert.Equal(t, nil, ack.Validate())
+	}
+
+	assert.Equal(t, 1, len(response.SubmissionReceipts))
+	assert.Equal(t, irs_990.SubmissionIdType("00000020193037000001"), response.SubmissionReceipts[0].SubmissionId)
+	assert.Equal(t, nil, response.SubmissionReceipts[0].Validate())
+	assert.Equal(t, 1, len(response.StatusRecords))
+	assert.Equal(t, irs_990.AcceptedAckCreatedStatus, response.StatusRecords[0].SubmissionStatusTxt)
+	assert.Equal(t, nil, response.StatusRecords[0].Validate())
+
+	// the MIME header is read from the body without content type
+	message := append([]byte("MIME-Version: 1.0\r\nContent-Type: "+utils.TransmissionContentType(writer.Boundary())+"\r\n\r\n"), body.Bytes()...)
+	response, err = ParseResponse("", message)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(response.Acknowledgements))
+
+	_, err = ParseResponse("text/xml", body.Bytes())
+	assert.True(t, errors.Is(err, ErrNotMultipart))
+
+	body.Reset()
+	writer = multipart.NewWriter(body)
+	part, err = writer.CreatePart(textproto.MIMEHeader{"Content-Location": {utils.AttachmentLocation}})
+	assert.Equal(t, nil, err)
+	_, err = part.Write(attachment)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, writer.Close())
+	_, err = ParseResponse(utils.TransmissionContentType(writer.Boundary()), body.Bytes())
+	assert.True(t, errors.Is(err, ErrMissingEnvelope))
+
+	// parts, uncompressed data and nested zips are read up to their limits
+	response = &Response{Files: map[string][]byte{}}
+	nested := zipFiles(map[string][]byte{"a.zip": zipFiles(map[string][]byte{"b.zip": zipFiles(map[string][]byte{"status.xml": []byte(status)})})})
+	assert.Equal(t, nil, response.unpack("", nested, 1, newReadBudget()))
+	assert.True(t, errors.Is(response.unpack("", zipFiles(map[string][]byte{"c.zip": nested}), 1, newReadBudget()), ErrTooDeep))
+	assert.True(t, errors.Is(response.unpack("", attachment, 1, &readBudget{left: 16}), ErrTooLarge))
+	compressed := new(bytes.Buffer)
+	gz = gzip.NewWriter(compressed)
+	_, err = gz.Write(attachment)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, gz.Close())
+	_, err = gunzipData(compressed.Bytes(), &readBudget{left: 16})
+	assert.True(t, errors.Is(err, ErrTooLarge))
+
+	maxPartSize := MaxPartSize
+	defer func() { MaxPartSize = maxPartSize }()
+	MaxPartSize = 16
+	_, err = ParseResponse("", message)
+	assert.True(t, errors.Is(err, ErrTooLarge))
+}
+
+func TestSoapEnvelope(t *testing.T) {
+	ts := irs_990.TimestampType(time.Date(2020, 10, 26, 7, 28, 40, 0, time.UTC))
+	envelope := Envelope{
+		Header: &SoapHeader{
+			TransmissionHeader: &TransmissionHeaderType{
+				TransmissionId: "T-0001",
+				Timestamp:      &ts,
+				Transmitter:    TransmitterDetail{ETIN: "12345"},
+			},
+			AcknowledgementHeader: &AcknowledgementHeaderType{AcknowledgementTimestamp: ts},
+		},
+		Body: &SoapBody{
+			TransmissionAcknowledgement: &TransmissionAcknowledgementType{
+				TransmissionId:     "T-0001",
+				TransmissionStatus: "R",
+				Errors: &TransmissionErrors{
+					ErrorCount: 1,
+					Error: []TransmissionError{{
+						ErrorCategory: "Incorrect Data",
+						ErrorMessage:  "Transmission header is invalid",
+						RuleNumber:    "T0000-001",
+						Severity:      "Reject and Stop",
+						ErrorId:       1,
+					}},
+				},
+				GTXKey: "GTX0001",
+			},
+			Fault: &Fault{
+				Faultcode:   "SOAP-ENV:Server",
+				Faultstring: "MeF service is unavailable",
+				Detail:      &FaultDetail{Content: `<ErrorMessage xmlns="http://www.irs.gov/a2a/mef/MeFHeader.xsd">unavailable</ErrorMessage>`},
+			},
+		},
+	}
+	assert.Equal(t, nil, envelope.Validate())
+
+	buf, err := xml.Marshal(envelope)
+	assert.Equal(t, nil, err)
+	assert.True(t, bytes.Contains(buf, []byte(`<SOAP-ENV:Fault><faultcode>SOAP-ENV:Server</faultcode>`)))
+	assert.True(t, bytes.Contains(buf, []byte(`<TransmissionHeader xmlns="http://www.irs.gov/efile">`)))
+	assert.True(t, bytes.Contains(buf, []byte(`<detail><ErrorMessage xmlns=`)))
+
+	parsed := Envelope{}
+	err = xml.Unmarshal(buf, &parsed)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, envelope.Header, parsed.Header)
+	assert.Equal(t, envelope.Body, parsed.Body)
+
+	envelope.Body.TransmissionAcknowledgement.TransmissionStatus = "X"
+	assert.NotNil(t, envelope.Validate())
+
+	err = xml.Unmarshal([]byte(`<Envelope xmlns="http://www.irs.gov/efile"><Body/></Envelope>`), &parsed)
+	assert.NotNil(t, err)
+}
+
+func TestBuildHeader(t *testing.T) {
+	now := time.Date(2020, 10, 26, 7, 28, 40, 791485804, time.FixedZone("EST", -5*3600))
+
+	_, err := NewMessageIdGenerator("1234", irs_990.NewMemorySequenceStore())
+	assert.NotNil(t, err)
+	_, err = NewMessageIdGenerator("12345", nil)
+	assert.True(t, errors.Is(err, ErrMissingSequenceStore))
+
+	generator, err := NewMessageIdGenerator("12345", irs_990.NewMemorySequenceStore())
+	assert.Equal(t, nil, err)
+	id, err := generator.Next(now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, MessageIdType("12345202030000000000"), id)
+	assert.Equal(t, nil, id.Validate())
+
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+	file := &irs_990.Irs990File{TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	file.Manifest = &irs_990.IRSSubmissionManifest{}
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+
+	transmission := &Irs990TransmissionFile{Attachments: []utils.IrsReturnFile{file}}
+	err = transmission.BuildHeader(generator, now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, transmission.Soap.Validate())
+
+	header := transmission.Soap.Header.Transmission
+	assert.Equal(t, MessageIdType("12345202030000000001"), header.MessageId)
+	assert.Equal(t, irs_990.ETINType("12345"), header.TransmitterDetail.ETIN)
+	list := transmission.Soap.Body.Manifest.SubmissionDataList
+	assert.Equal(t, 1, list.Cnt)
+	assert.Equal(t, file.Manifest.SubmissionId, list.SubmissionData[0].SubmissionId)
+
+	envelope, err := transmission.SOAPEnvelope()
+	assert.Equal(t, nil, err)
+	assert.True(t, bytes.Contains(envelope, []byte("<TransmissionTs>2020-10-26T07:28:40-05:00</TransmissionTs>")))
+	_, err = transmission.SOAPAttachment()
+	assert.Equal(t, nil, err)
+
+	// existing submission data is kept, a new attachment needs a submission id
+	transmission.Attachments = append(transmission.Attachments, &irs_990.Irs990File{})
+	err = transmission.BuildHeader(generator, now)
+	assert.True(t, errors.Is(err, ErrMissingSubmissionId))
+	transmission.Attachments = transmission.Attachments[:1]
+	err = transmission.BuildHeader(generator, now.Add(time.Hour))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, list.SubmissionData[0], transmission.Soap.Body.Manifest.SubmissionDataList.SubmissionData[0])
+	assert.Equal(t, MessageIdType("12345202030000000002"), transmission.Soap.Header.Transmission.MessageId)
+
+	// submission data is matched by submission id, not by position
+	other := &irs_990.Irs990File{Manifest: &irs_990.IRSSubmissionManifest{SubmissionId: "00000020193037000009"}}
+	transmission.Attachments = []utils.IrsReturnFile{other, file}
+	err = transmission.BuildHeader(generator, now.Add(2*time.Hour))
+	assert.Equal(t, nil, err)
+	data := transmission.Soap.Body.Manifest.SubmissionDataList.SubmissionData
+	assert.Equal(t, 2, len(data))
+	assert.Equal(t, other.Manifest.SubmissionId, data[0].SubmissionId)
+	assert.Equal(t, list.SubmissionData[0], data[1])
+
+	// submission data without attachment and repeated submission ids are mismatches
+	transmission.Attachments = []utils.IrsReturnFile{file}
+	err = transmission.BuildHeader(generator, now)
+	assert.True(t, errors.Is(err, ErrMismatchedSubmissionData))
+	assert.Contains(t, err.Error(), string(other.Manifest.SubmissionId))
+	transmission.Attachments = []utils.IrsReturnFile{other, file, other}
+	err = transmission.BuildHeader(generator, now)
+	assert.True(t, errors.Is(err, ErrMismatchedSubmissionData))
+}
+
+func TestUnpackSubmission(t *testing.T) {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &irs_990.Irs990File{Manifest: &irs_990.IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+	pdf := []byte("%PDF-1.4\n%%EOF\n")
+	err = file.AddAttachment(irs_990.Attachment{Name: "explanation.pdf", Data: pdf}, irs_990.BinaryAttachment{Desc: "GENERAL EXPLANATION ATTACHMENT", DocumentId: "RetDoc1038000002"})
+	assert.Equal(t, nil, err)
+	buf, err := file.ZipData()
+	assert.Equal(t, nil, err)
+
+	unpacked, err := UnpackSubmission(buf, utils.TestCd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(unpacked.Problems))
+	assert.Equal(t, irs_990.ReturnTypeCd("990"), unpacked.ReturnTypeCd)
+	assert.Equal(t, "xml/submission.xml", unpacked.ReturnXml)
+	assert.Equal(t, nil, unpacked.File.Validate())
+	result := unpacked.Irs990File()
+	assert.Equal(t, file.Manifest.SubmissionId, result.Manifest.SubmissionId)
+	assert.Equal(t, file.XmlData.String(), result.XmlData.String())
+	assert.Equal(t, 1, len(result.Attachments))
+	assert.Equal(t, pdf, result.Attachments[0].Data)
+
+	// the unpacked file packs again
+	again, err := unpacked.File.ZipData()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(buf), len(again))
+
+	writeZip := func(files map[string][]byte, names ...string) []byte {
+		zipBuf := new(bytes.Buffer)
+		writer := zip.NewWriter(zipBuf)
+		for _, name := range names {
+			f, err := writer.Create(name)
+			assert.Equal(t, nil, err)
+			_, err = f.Write(files[name])
+			assert.Equal(t, nil, err)
+		}
+		assert.Equal(t, nil, writer.Close())
+		return zipBuf.Bytes()
+	}
+	files := map[string][]byte{
+		"xml/return.xml":         returnBuf,
+		"xml/second.xml":         returnBuf,
+		"notes.txt":              []byte("notes"),
+		"attachment/scan.tiff":   []byte("II*"),
+		"attachment/explain.pdf": pdf,
+		"manifest/manifest.xml":  manifestBuf,
+	}
+
+	unpacked, err = UnpackSubmission(writeZip(files, "xml/return.xml", "xml/second.xml", "notes.txt", "attachment/scan.tiff", "attachment/explain.pdf"), utils.ProductionCd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "xml/return.xml", unpacked.ReturnXml)
+	assert.Nil(t, unpacked.Irs990File().Manifest)
+	assert.Equal(t, 2, len(unpacked.Irs990File().Attachments))
+	assert.Equal(t, 4, len(unpacked.Problems))
+	assert.True(t, errors.Is(unpacked.Problems[0], ErrMultipleReturnXml))
+	assert.True(t, errors.Is(unpacked.Problems[1], ErrUnexpectedZipFile))
+	assert.True(t, errors.Is(unpacked.Problems[2], irs_990.ErrInvalidAttachmentName))
+	assert.True(t, errors.Is(unpacked.Problems[3], ErrMissingManifestXml))
+
+	unpacked, err = UnpackSubmission(writeZip(map[string][]byte{
+		"xml/return.xml":        returnBuf,
+		"manifest/manifest.xml": manifestBuf,
+		"attachment/scan.pdf":   []byte("II*"),
+	}, "xml/return.xml", "manifest/manifest.xml", "attachment/scan.pdf"), utils.ProductionCd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(unpacked.Problems))
+	assert.True(t, errors.Is(unpacked.Problems[0], irs_990.ErrNotPDFAttachment))
+
+	_, err = UnpackSubmission(writeZip(files, "manifest/manifest.xml"), utils.ProductionCd)
+	assert.True(t, errors.Is(err, ErrMissingReturnXml))
+
+	files["xml/return.xml"] = bytes.Replace(returnBuf, []byte("<ReturnTypeCd>990</ReturnTypeCd>"), []byte("<ReturnTypeCd>1120</ReturnTypeCd>"), 1)
+	_, err = UnpackSubmission(writeZip(files, "xml/return.xml", "manifest/manifest.xml"), utils.ProductionCd)
+	assert.True(t, errors.Is(err, utils.ErrUnknownReturnType))
+
+	// return types of the schema without return structs
+	files["xml/return.xml"] = bytes.Replace(returnBuf, []byte("<ReturnTypeCd>990</ReturnTypeCd>"), []byte("<ReturnTypeCd>990EZ</ReturnTypeCd>"), 1)
+	_, err = UnpackSubmission(writeZip(files, "xml/return.xml", "manifest/manifest.xml"), utils.ProductionCd)
+	assert.True(t, errors.Is(err, utils.ErrFailedCreateTaxReturn))
+
+	_, err = UnpackSubmission([]byte("not a zip"), utils.ProductionCd)
+	assert.NotNil(t, err)
+
+	// the submission zips of a transmission attachment are named by submission id
+	submission := writeZip(map[string][]byte{"xml/return.xml": returnBuf, "manifest/manifest.xml": manifestBuf}, "xml/return.xml", "manifest/manifest.xml")
+	attachment := writeZip(map[string][]byte{"00000020201230000001": submission}, "00000020201230000001")
+	buf, err = SubmissionZip(attachment, "00000020201230000001")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, submission, buf)
+	_, err = SubmissionZip(attachment, "00000020201230000002")
+	assert.True(t, errors.Is(err, ErrMissingSubmissionZip))
+
+	maxUncompressedSize := MaxUncompressedSize
+	defer func() { MaxUncompressedSize = maxUncompressedSize }()
+	// the return fits, the manifest is beyond the limit
+	files["xml/return.xml"] = returnBuf
+	MaxUncompressedSize = int64(len(returnBuf))
+	_, err = UnpackSubmission(writeZip(files, "xml/return.xml", "manifest/manifest.xml"), utils.ProductionCd)
+	assert.True(t, errors.Is(err, ErrTooLarge))
+}
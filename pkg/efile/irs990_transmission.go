@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"errors"
+	"reflect"
 	"regexp"
 
 	"github.com/moov-io/1120x/pkg/irs_990"
@@ -29,6 +30,7 @@ func (r Irs990TransmissionFile) SOAPEnvelope() ([]byte, error) {
 	return xml.Marshal(r.Soap)
 }
 
+// SOAPAttachment zips the attachments, an attachment with blocking validation issues stops the attachment
 func (r Irs990TransmissionFile) SOAPAttachment() ([]byte, error) {
 	if r.Soap.Body == nil || r.Soap.Body.Manifest == nil {
 		return nil, errors.New("don't include manifest")
@@ -43,7 +45,6 @@ func (r Irs990TransmissionFile) SOAPAttachment() ([]byte, error) {
 
 	// Create a new zip archive.
 	writer := zip.NewWriter(fileBuf)
-	defer writer.Close()
 
 	submission := data.SubmissionData
 	for index, attachment := range r.Attachments {
@@ -62,11 +63,25 @@ func (r Irs990TransmissionFile) SOAPAttachment() ([]byte, error) {
 		}
 	}
 
-	return fileBuf.Bytes(), writer.Close()
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return fileBuf.Bytes(), nil
+}
+
+// TransmissionMessage returns the MIME multi-part transmission file of the SOAP envelope and attachment
+func (r Irs990TransmissionFile) TransmissionMessage(options *utils.TransmissionOptions) ([]byte, error) {
+	return utils.TransmissionMessage(r, options)
 }
 
+// Version returns return version of the attachments, the version of the first attachment is used
 func (r Irs990TransmissionFile) Version() string {
-	return "2015v2.0"
+	for _, attachment := range r.Attachments {
+		if version := attachment.Version(); len(version) > 0 {
+			return version
+		}
+	}
+	return irs_990.Version
 }
 
 // common types for elements
@@ -125,3 +140,81 @@ type TransmissionManifest struct {
 func (r TransmissionManifest) Validate() error {
 	return utils.Validate(&r)
 }
+
+type TransmissionHeaderType struct {
+	TransmissionId irs_990.IdType         `xml:"TransmissionId"`
+	Timestamp      *irs_990.TimestampType `xml:"Timestamp,omitempty" json:",omitempty"`
+	Transmitter    TransmitterDetail      `xml:"Transmitter"`
+}
+
+func (r TransmissionHeaderType) Validate() error {
+	return utils.Validate(&r)
+}
+
+type AcknowledgementHeaderType struct {
+	AcknowledgementTimestamp irs_990.TimestampType `xml:"AcknowledgementTimestamp"`
+}
+
+func (r AcknowledgementHeaderType) Validate() error {
+	return utils.Validate(&r)
+}
+
+type TransmissionAcknowledgementType struct {
+	TransmissionId             irs_990.IdType         `xml:"TransmissionId,omitempty" json:",omitempty"`
+	TransmissionTimestamp      *irs_990.TimestampType `xml:"TransmissionTimestamp,omitempty" json:",omitempty"`
+	TransmissionStatus         StatusType             `xml:"TransmissionStatus"`
+	Errors                     *TransmissionErrors    `xml:"Errors,omitempty" json:",omitempty"`
+	GTXKey                     GTXKeyType             `xml:"GTXKey"`
+	GTXKeyOfOriginalDuplicated GTXKeyType             `xml:"GTXKeyOfOriginalDuplicated,omitempty" json:",omitempty"`
+}
+
+func (r TransmissionAcknowledgementType) Validate() error {
+	return utils.Validate(&r)
+}
+
+// Must be one of A (accepted) or R (rejected)
+type StatusType string
+
+func (r StatusType) Validate() error {
+	for _, vv := range []string{
+		"A", "R",
+	} {
+		if reflect.DeepEqual(string(r), vv) {
+			return nil
+		}
+	}
+	return errors.New("StatusType is invalid")
+}
+
+// Must be at most 20 characters
+type GTXKeyType string
+
+func (r GTXKeyType) Validate() error {
+	if len(r) > 20 {
+		return errors.New("GTXKeyType is invalid")
+	}
+	return nil
+}
+
+type TransmissionErrors struct {
+	Error      []TransmissionError `xml:"Error"`
+	ErrorCount int                 `xml:"errorCount,attr"`
+}
+
+func (r TransmissionErrors) Validate() error {
+	return utils.Validate(&r)
+}
+
+type TransmissionError struct {
+	XPath         string `xml:"XPath,omitempty" json:",omitempty"`
+	ErrorCategory string `xml:"ErrorCategory"`
+	ErrorMessage  string `xml:"ErrorMessage"`
+	RuleNumber    string `xml:"RuleNumber"`
+	Severity      string `xml:"Severity"`
+	DataValue     string `xml:"DataValue,omitempty" json:",omitempty"`
+	ErrorId       int    `xml:"errorId,attr"`
+}
+
+func (r TransmissionError) Validate() error {
+	return utils.Validate(&r)
+}
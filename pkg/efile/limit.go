@@ -0,0 +1,59 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package efile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Limits of the inbound responses and submission zips, a larger or deeper input is an error
+var (
+	// MaxPartSize is the largest MIME part of a response
+	MaxPartSize int64 = 100 << 20
+	// MaxUncompressedSize is the largest total of the data uncompressed from a response or a submission zip
+	MaxUncompressedSize int64 = 200 << 20
+	// MaxZipDepth is the deepest nesting of the zips in a response attachment, the attachment is at depth 1
+	MaxZipDepth = 3
+)
+
+var (
+	// ErrTooLarge is given when a part or the uncompressed data is larger than its limit
+	ErrTooLarge = errors.New("data is larger than the limit")
+	// ErrTooDeep is given when the zips of a response attachment are nested deeper than MaxZipDepth
+	ErrTooDeep = errors.New("zips are nested deeper than the limit")
+)
+
+// readLimited reads r, data larger than limit is an error
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	buf, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, limit)
+	}
+	return buf, nil
+}
+
+// readBudget is the uncompressed data left to read from a response or a submission zip
+type readBudget struct {
+	left int64
+}
+
+func newReadBudget() *readBudget {
+	return &readBudget{left: MaxUncompressedSize}
+}
+
+// read reads r within the budget left
+func (b *readBudget) read(r io.Reader) ([]byte, error) {
+	buf, err := readLimited(r, b.left)
+	if err != nil {
+		return nil, err
+	}
+	b.left -= int64(len(buf))
+	return buf, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package efile
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+)
+
+var (
+	// ErrExhaustedMessageIds is given when all message ids of an ETIN and day were generated
+	ErrExhaustedMessageIds = errors.New("message ids of the day are exhausted")
+	// ErrMissingSequenceStore is given when a message id generator is created without a sequence store
+	ErrMissingSequenceStore = errors.New("message id generator needs a sequence store")
+	// ErrMissingSubmissionId is given when an attachment of the transmission hasn't a submission id
+	ErrMissingSubmissionId = errors.New("attachment hasn't a submission id")
+	// ErrMismatchedSubmissionData is given when the submission data of a transmission doesn't match its attachments
+	ErrMismatchedSubmissionData = errors.New("submission data doesn't match the attachments")
+)
+
+var (
+	messageIdSuffixLen = 8
+	// maxMessageSequence is the largest sequence number that fits in the suffix
+	maxMessageSequence = uint64(2821109907455) // 36^8 - 1
+)
+
+// MessageIdGenerator generates message ids: the ETIN, the date as ccyyddd
+// and a sequence number as 8 lower case alphanumeric characters
+type MessageIdGenerator struct {
+	ETIN  irs_990.ETINType
+	Store irs_990.SequenceStore
+}
+
+// NewMessageIdGenerator returns a generator of the ETIN. MeF rejects a repeated message id,
+// so the store has to keep its numbers across restarts, like a FileSequenceStore does.
+func NewMessageIdGenerator(etin irs_990.ETINType, store irs_990.SequenceStore) (*MessageIdGenerator, error) {
+	if err := etin.Validate(); err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, ErrMissingSequenceStore
+	}
+	return &MessageIdGenerator{ETIN: etin, Store: store}, nil
+}
+
+// Next returns a new message id of the date
+func (g *MessageIdGenerator) Next(date time.Time) (MessageIdType, error) {
+	prefix := fmt.Sprintf("%s%04d%03d", g.ETIN, date.Year(), date.YearDay())
+	seq, err := g.Store.Next(prefix)
+	if err != nil {
+		return "", err
+	}
+	if seq > maxMessageSequence {
+		return "", fmt.Errorf("%w: %s", ErrExhaustedMessageIds, prefix)
+	}
+	suffix := strconv.FormatUint(seq, 36)
+	return MessageIdType(prefix + strings.Repeat("0", messageIdSuffixLen-len(suffix)) + suffix), nil
+}
+
+// BuildHeader fills the transmission header and the transmission manifest of the transmission.
+// The header gets a new message id of the generator, the ETIN of the generator and now as
+// transmission timestamp. The submission data list gets an entry per attachment keyed by the
+// submission id of its manifest, existing entries are kept and new entries are postmarked with now.
+// An entry without attachment or an attachment repeating a submission id is a mismatch.
+func (r *Irs990TransmissionFile) BuildHeader(generator *MessageIdGenerator, now time.Time) error {
+	ts := irs_990.TimestampType(now)
+
+	if r.Soap.Body == nil {
+		r.Soap.Body = &SoapBody{}
+	}
+	if r.Soap.Body.Manifest == nil {
+		r.Soap.Body.Manifest = &TransmissionManifest{}
+	}
+	list := &r.Soap.Body.Manifest.SubmissionDataList
+	existing := map[irs_990.SubmissionIdType]SubmissionDataType{}
+	for _, entry := range list.SubmissionData {
+		existing[entry.SubmissionId] = entry
+	}
+
+	data := make([]SubmissionDataType, len(r.Attachments))
+	used := map[irs_990.SubmissionIdType]bool{}
+	for index, attachment := range r.Attachments {
+		file, ok := attachment.(*irs_990.Irs990File)
+		if !ok || file.Manifest == nil || len(file.Manifest.SubmissionId) == 0 {
+			return fmt.Errorf("%w: attachment %d", ErrMissingSubmissionId, index)
+		}
+		id := file.Manifest.SubmissionId
+		if used[id] {
+			return fmt.Errorf("%w: submission id %s is repeated", ErrMismatchedSubmissionData, id)
+		}
+		used[id] = true
+		entry, ok := existing[id]
+		if !ok {
+			entry = SubmissionDataType{SubmissionId: id, ElectronicPostmarkTs: ts}
+		}
+		data[index] = entry
+	}
+	for _, entry := range list.SubmissionData {
+		if !used[entry.SubmissionId] {
+			return fmt.Errorf("%w: submission id %s hasn't an attachment", ErrMismatchedSubmissionData, entry.SubmissionId)
+		}
+	}
+
+	id, err := generator.Next(now)
+	if err != nil {
+		return err
+	}
+	if r.Soap.Header == nil {
+		r.Soap.Header = &SoapHeader{}
+	}
+	r.Soap.Header.Transmission = &IFATransmissionHeaderType{
+		MessageId:         id,
+		TransmissionTs:    &ts,
+		TransmitterDetail: TransmitterDetail{ETIN: generator.ETIN},
+	}
+	list.SubmissionData = data
+	list.Cnt = len(data)
+	return nil
+}
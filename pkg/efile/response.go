@@ -0,0 +1,227 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package efile
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"path"
+	"strings"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	// ErrNotMultipart is given when the response isn't a MIME multi-part message
+	ErrNotMultipart = errors.New("response isn't a MIME multi-part message")
+	// ErrMissingEnvelope is given when the response hasn't a SOAP envelope part
+	ErrMissingEnvelope = errors.New("response hasn't a SOAP envelope")
+)
+
+// Response is an inbound MeF response, the SOAP envelope with the unpacked attachment
+type Response struct {
+	Soap Envelope
+	// Envelope is the raw SOAP envelope part
+	Envelope []byte `json:",omitempty"`
+	// Attachment is the attachment zip, uncompressed when the part was gzipped
+	Attachment []byte `json:",omitempty"`
+	// Files are the files of the attachment zip, nested zips are unpacked as "<zip>/<file>".
+	// Only the xml files are decoded, other files like PDF attachments are kept as they are.
+	Files map[string][]byte `json:",omitempty"`
+
+	Acknowledgements   []irs_990.Acknowledgement      `json:",omitempty"`
+	SubmissionReceipts []irs_990.SubmissionReceiptGrp `json:",omitempty"`
+	StatusRecords      []irs_990.StatusRecordGrp      `json:",omitempty"`
+	AckNotifications   []irs_990.AckNotification      `json:",omitempty"`
+}
+
+// ParseResponse parses a MIME multi-part MeF response body.
+// The content type gives the boundary, when it's empty the MIME header is read from the body.
+func ParseResponse(contentType string, body []byte) (*Response, error) {
+	reader := io.Reader(bytes.NewReader(body))
+	if len(contentType) == 0 {
+		msg, err := mail.ReadMessage(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNotMultipart, err)
+		}
+		contentType = msg.Header.Get("Content-Type")
+		reader = msg.Body
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(strings.ToLower(mediaType), "multipart/") || len(params["boundary"]) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotMultipart, contentType)
+	}
+
+	response := &Response{Files: map[string][]byte{}}
+	budget := newReadBudget()
+	parts := multipart.NewReader(reader, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := readLimited(part, MaxPartSize)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(part.Header.Get("Content-Encoding"), "gzip") {
+			if data, err = gunzipData(data, budget); err != nil {
+				return nil, err
+			}
+		}
+
+		if response.Envelope == nil && isEnvelopePart(part.Header, params["start"]) {
+			response.Envelope = data
+		} else if response.Attachment == nil {
+			response.Attachment = data
+		}
+	}
+
+	if response.Envelope == nil {
+		return nil, ErrMissingEnvelope
+	}
+	if err = xml.Unmarshal(response.Envelope, &response.Soap); err != nil {
+		return nil, err
+	}
+	if len(response.Attachment) > 0 {
+		if err = response.unpack("", response.Attachment, 1, budget); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// isEnvelopePart reports whether the part is the envelope. The envelope is the part with the
+// Content-ID of the start parameter, else the part of the envelope location, else the xml part.
+func isEnvelopePart(header textproto.MIMEHeader, start string) bool {
+	if id := header.Get("Content-ID"); len(id) > 0 && len(start) > 0 {
+		return id == start
+	}
+	if location := header.Get("Content-Location"); len(location) > 0 {
+		return strings.EqualFold(location, utils.EnvelopeLocation)
+	}
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "text/xml" || mediaType == "application/xop+xml"
+}
+
+// unpack reads the files of a zip at the depth, nested zips are unpacked and only xml files are decoded
+func (r *Response) unpack(dir string, data []byte, depth int, budget *readBudget) error {
+	if depth > MaxZipDepth {
+		return fmt.Errorf("%w: %s", ErrTooDeep, dir)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return err
+		}
+		buf, err := budget.read(reader)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+
+		name := path.Join(dir, file.Name)
+		if bytes.HasPrefix(buf, []byte("PK\x03\x04")) {
+			if err = r.unpack(name, buf, depth+1, budget); err != nil {
+				return err
+			}
+			continue
+		}
+		r.Files[name] = buf
+		if !strings.EqualFold(path.Ext(name), ".xml") {
+			continue
+		}
+		if err = r.decode(buf); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// decode adds the acknowledgements, submission receipts, status records and ack notifications found in a xml file
+func (r *Response) decode(buf []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(buf))
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "AcknowledgementList":
+			var list irs_990.AcknowledgementList
+			err = decoder.DecodeElement(&list, &start)
+			r.Acknowledgements = append(r.Acknowledgements, list.Acknowledgement...)
+		case "Acknowledgement":
+			var ack irs_990.Acknowledgement
+			err = decoder.DecodeElement(&ack, &start)
+			r.Acknowledgements = append(r.Acknowledgements, ack)
+		case "SubmissionReceiptList":
+			var list irs_990.SubmissionReceiptList
+			err = decoder.DecodeElement(&list, &start)
+			r.SubmissionReceipts = append(r.SubmissionReceipts, list.SubmissionReceiptGrp...)
+		case "SubmissionReceiptGrp":
+			var receipt irs_990.SubmissionReceiptGrp
+			err = decoder.DecodeElement(&receipt, &start)
+			r.SubmissionReceipts = append(r.SubmissionReceipts, receipt)
+		case "StatusRecordList":
+			var list irs_990.StatusRecordList
+			err = decoder.DecodeElement(&list, &start)
+			r.StatusRecords = append(r.StatusRecords, list.StatusRecordGrp...)
+		case "StatusRecordGrp":
+			var record irs_990.StatusRecordGrp
+			err = decoder.DecodeElement(&record, &start)
+			r.StatusRecords = append(r.StatusRecords, record)
+		case "AckNotificationList":
+			var list irs_990.AckNotificationList
+			err = decoder.DecodeElement(&list, &start)
+			r.AckNotifications = append(r.AckNotifications, list.AckNotification...)
+		case "AckNotification":
+			var notification irs_990.AckNotification
+			err = decoder.DecodeElement(&notification, &start)
+			r.AckNotifications = append(r.AckNotifications, notification)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func gunzipData(data []byte, budget *readBudget) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return budget.read(reader)
+}
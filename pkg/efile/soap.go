@@ -6,100 +6,78 @@ package efile
 
 import (
 	"encoding/xml"
+
 	"github.com/moov-io/1120x/pkg/utils"
 )
 
-type Envelope struct {
-	Xmlns  string      `xml:"xmlns,attr,omitempty" json:",omitempty"`
-	Items  []string    `xml:",any"`
-	Header *SoapHeader `xml:"Header,omitempty" json:",omitempty"`
-	Body   *SoapBody   `xml:"Body" json:",omitempty"`
-}
+// Namespaces of the SOAP envelope
+const (
+	SoapEnvNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	EfileNamespace   = "http://www.irs.gov/efile"
+)
 
-func (r *Envelope) Init() error {
-	r.Xmlns = "http://www.irs.gov/efile"
-	return nil
+// Envelope is the SOAP 1.1 envelope of Common/SOAP.xsd
+type Envelope struct {
+	XMLName xml.Name    `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope" json:"-"`
+	Header  *SoapHeader `xml:"http://schemas.xmlsoap.org/soap/envelope/ Header,omitempty" json:",omitempty"`
+	Body    *SoapBody   `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
 }
 
 func (r Envelope) Validate() error {
 	return utils.Validate(&r)
 }
 
-type SoapBody struct {
-	Body     []string
-	Manifest *TransmissionManifest
-}
-
-func (r SoapBody) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	var output struct {
-		ArrayType string   `xml:"http://schemas.xmlsoap.org/wsdl/ arrayType,attr"`
-		Items     []string `xml:"item"`
+// MarshalXML writes the envelope with the SOAP-ENV prefix, header and body entries are in the efile namespace
+func (r Envelope) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	output := soapEnvelope{
+		SoapEnv: SoapEnvNamespace,
+		Header:  r.Header,
 	}
-	output.Items = r.Body
-	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: "", Local: "xmlns:ns1"}, Value: "http://www.w3.org/2001/XMLSchema"})
-	output.ArrayType = "ns1:anyType[]"
-	return e.EncodeElement(&output, start)
-}
-func (r *SoapBody) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
-	r.Manifest = nil
-	var tok xml.Token
-	for tok, err = d.Token(); err == nil; tok, err = d.Token() {
-		if tok, ok := tok.(xml.StartElement); ok {
-			var item string
-			if err = d.DecodeElement(&item, &tok); err == nil {
-				r.Body = append(r.Body, item)
-				if r.Manifest == nil {
-					var manifest TransmissionManifest
-					if err = xml.Unmarshal([]byte(item), &manifest); err == nil {
-						r.Manifest = &manifest
-					}
-				}
-			}
-		}
-		if _, ok := tok.(xml.EndElement); ok {
-			break
+	if r.Body != nil {
+		output.Body = &soapBody{
+			Manifest:                    r.Body.Manifest,
+			TransmissionAcknowledgement: r.Body.TransmissionAcknowledgement,
+			Fault:                       r.Body.Fault,
 		}
 	}
-	return err
+	return e.Encode(&output)
 }
 
+// SoapHeader holds the header entries of the IFA and EMS messages
 type SoapHeader struct {
-	Header       []string
-	Transmission *IFATransmissionHeaderType
+	// Transmission is the transmission header of efileMessageIFA.xsd
+	Transmission *IFATransmissionHeaderType `xml:"http://www.irs.gov/efile IFATransmissionHeader,omitempty" json:",omitempty"`
+	// TransmissionHeader is the transmission header of efileMessageEMS.xsd
+	TransmissionHeader *TransmissionHeaderType `xml:"http://www.irs.gov/efile TransmissionHeader,omitempty" json:",omitempty"`
+	// AcknowledgementHeader is the acknowledgement header of efileMessageEMS.xsd
+	AcknowledgementHeader *AcknowledgementHeaderType `xml:"http://www.irs.gov/efile AcknowledgementHeader,omitempty" json:",omitempty"`
 }
 
-func (r SoapHeader) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	var output struct {
-		ArrayType string   `xml:"http://schemas.xmlsoap.org/wsdl/ arrayType,attr"`
-		Items     []string `xml:"item"`
-	}
-	output.Items = r.Header
-	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: "", Local: "xmlns:ns1"}, Value: "http://www.w3.org/2001/XMLSchema"})
-	output.ArrayType = "ns1:anyType[]"
-	return e.EncodeElement(&output, start)
+func (r SoapHeader) Validate() error {
+	return utils.Validate(&r)
+}
+
+// SoapBody holds the body entries of the IFA and EMS messages
+type SoapBody struct {
+	Manifest                    *TransmissionManifest            `xml:"http://www.irs.gov/efile TransmissionManifest,omitempty" json:",omitempty"`
+	TransmissionAcknowledgement *TransmissionAcknowledgementType `xml:"http://www.irs.gov/efile TransmissionAcknowledgement,omitempty" json:",omitempty"`
+	Fault                       *Fault                           `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty" json:",omitempty"`
+}
+
+func (r SoapBody) Validate() error {
+	return utils.Validate(&r)
+}
+
+// soapEnvelope and soapBody are the prefixed forms of the envelope written by MarshalXML
+type soapEnvelope struct {
+	XMLName xml.Name    `xml:"SOAP-ENV:Envelope"`
+	SoapEnv string      `xml:"xmlns:SOAP-ENV,attr"`
+	Header  *SoapHeader `xml:"SOAP-ENV:Header,omitempty"`
+	Body    *soapBody   `xml:"SOAP-ENV:Body"`
 }
-func (r *SoapHeader) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
-	var tok xml.Token
-	r.Transmission = nil
-	for tok, err = d.Token(); err == nil; tok, err = d.Token() {
-		if tok, ok := tok.(xml.StartElement); ok {
-			{
-				var item string
-				if err = d.DecodeElement(&item, &tok); err == nil {
-					r.Header = append(r.Header, item)
-					if r.Transmission == nil {
-						var header IFATransmissionHeaderType
-						if err = xml.Unmarshal([]byte(item), &header); err == nil {
-							r.Transmission = &header
-						}
-					}
-				}
-			}
-		}
-		if _, ok := tok.(xml.EndElement); ok {
-			break
-		}
-	}
 
-	return err
+type soapBody struct {
+	Manifest                    *TransmissionManifest            `xml:"http://www.irs.gov/efile TransmissionManifest,omitempty"`
+	TransmissionAcknowledgement *TransmissionAcknowledgementType `xml:"http://www.irs.gov/efile TransmissionAcknowledgement,omitempty"`
+	Fault                       *Fault                           `xml:"SOAP-ENV:Fault,omitempty"`
 }
@@ -0,0 +1,188 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package efile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	// ErrMissingReturnXml is given when the submission zip hasn't a return in the xml directory
+	ErrMissingReturnXml = errors.New("submission zip hasn't a return in the xml directory")
+	// ErrMultipleReturnXml is given when the submission zip has more than one file in the xml directory
+	ErrMultipleReturnXml = errors.New("submission zip has more than one file in the xml directory")
+	// ErrMissingManifestXml is given when the submission zip hasn't manifest/manifest.xml
+	ErrMissingManifestXml = errors.New("submission zip hasn't manifest/manifest.xml")
+	// ErrUnexpectedZipFile is given when the submission zip has a file outside of the MeF layout
+	ErrUnexpectedZipFile = errors.New("submission zip has an unexpected file")
+	// ErrDuplicatedZipFile is given when the submission zip has a file name more than once
+	ErrDuplicatedZipFile = errors.New("submission zip has a duplicated file")
+	// ErrMissingSubmissionZip is given when the transmission attachment hasn't the submission zip of a submission id
+	ErrMissingSubmissionZip = errors.New("transmission attachment hasn't the submission zip")
+)
+
+var (
+	zipXmlDir        = "xml"
+	zipManifestFile  = "manifest/manifest.xml"
+	zipAttachmentDir = "attachment"
+)
+
+// UnpackedSubmission is a submission zip read back into a return file
+type UnpackedSubmission struct {
+	// File is the return file of the return type, the manifest is nil when the zip hasn't one
+	File         utils.IrsReturnFile
+	ReturnTypeCd irs_990.ReturnTypeCd
+	// ReturnXml is the name of the return file in the zip
+	ReturnXml string
+	// Problems are the structural problems of the zip, like unexpected files or a missing manifest
+	Problems []error
+}
+
+// Irs990File returns the return file as an IRS 990 file, nil for other return types
+func (r UnpackedSubmission) Irs990File() *irs_990.Irs990File {
+	file, _ := r.File.(*irs_990.Irs990File)
+	return file
+}
+
+// UnpackSubmission reads a submission zip, the inverse of ZipData. The zip has the return in
+// the xml directory, manifest/manifest.xml and the binary attachments in the attachment directory.
+// The return type is taken from ReturnTypeCd of the return header, only IRS 990 returns can be read.
+// A zip without return or with a return that can't be read is an error, the other
+// structural problems are reported with the unpacked submission. The files are read up to
+// MaxUncompressedSize. testCd is the test indicator
+// of the transmission of the submission, see Irs990File.TestCd.
+func UnpackSubmission(buf []byte, testCd string) (*UnpackedSubmission, error) {
+	reader, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, err
+	}
+
+	unpacked := &UnpackedSubmission{}
+	budget := newReadBudget()
+	var returnXml, manifest []byte
+	var attachments []irs_990.Attachment
+	seen := map[string]bool{}
+
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Clean(strings.ReplaceAll(f.Name, "\\", "/"))
+		if seen[name] {
+			unpacked.Problems = append(unpacked.Problems, fmt.Errorf("%w: %s", ErrDuplicatedZipFile, name))
+			continue
+		}
+		seen[name] = true
+
+		dir, base := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+		switch {
+		case name == zipManifestFile:
+			if manifest, err = readZipFile(f, budget); err != nil {
+				return nil, err
+			}
+		case dir == zipXmlDir && strings.EqualFold(path.Ext(base), ".xml"):
+			if len(unpacked.ReturnXml) > 0 {
+				unpacked.Problems = append(unpacked.Problems, fmt.Errorf("%w: %s", ErrMultipleReturnXml, name))
+				continue
+			}
+			if returnXml, err = readZipFile(f, budget); err != nil {
+				return nil, err
+			}
+			unpacked.ReturnXml = name
+		case dir == zipAttachmentDir:
+			data, err := readZipFile(f, budget)
+			if err != nil {
+				return nil, err
+			}
+			attachment := irs_990.Attachment{Name: base, Data: data}
+			if err = attachment.Validate(); err != nil {
+				unpacked.Problems = append(unpacked.Problems, err)
+			}
+			attachments = append(attachments, attachment)
+		default:
+			unpacked.Problems = append(unpacked.Problems, fmt.Errorf("%w: %s", ErrUnexpectedZipFile, name))
+		}
+	}
+
+	if len(unpacked.ReturnXml) == 0 {
+		return nil, ErrMissingReturnXml
+	}
+	if manifest == nil {
+		unpacked.Problems = append(unpacked.Problems, ErrMissingManifestXml)
+	}
+
+	if unpacked.ReturnTypeCd, err = returnTypeCd(returnXml); err != nil {
+		return nil, err
+	}
+	if err = unpacked.ReturnTypeCd.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrUnknownReturnType, unpacked.ReturnTypeCd)
+	}
+
+	switch string(unpacked.ReturnTypeCd) {
+	case utils.IRS990ReturnTypeCode:
+		file := &irs_990.Irs990File{TestCd: testCd, Attachments: attachments}
+		if err = file.XmlData.Parse(returnXml); err != nil {
+			return nil, err
+		}
+		if manifest != nil {
+			file.Manifest = &irs_990.IRSSubmissionManifest{}
+			if err = xml.Unmarshal(manifest, file.Manifest); err != nil {
+				return nil, err
+			}
+		}
+		unpacked.File = file
+		return unpacked, nil
+	}
+	return nil, fmt.Errorf("%w: %s", utils.ErrFailedCreateTaxReturn, unpacked.ReturnTypeCd)
+}
+
+// SubmissionZip reads the submission zip of the submission id from a transmission attachment,
+// the zip of SOAPAttachment that has a submission zip per submission id
+func SubmissionZip(attachment []byte, id irs_990.SubmissionIdType) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(attachment), int64(len(attachment)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range reader.File {
+		if name := path.Clean(f.Name); name == string(id) || name == string(id)+".zip" {
+			return readZipFile(f, newReadBudget())
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMissingSubmissionZip, id)
+}
+
+func readZipFile(f *zip.File, budget *readBudget) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return budget.read(rc)
+}
+
+// returnTypeCd returns ReturnTypeCd of the return header
+func returnTypeCd(buf []byte) (irs_990.ReturnTypeCd, error) {
+	var header struct {
+		XMLName      xml.Name
+		ReturnTypeCd string `xml:"ReturnHeader>ReturnTypeCd"`
+	}
+	if err := xml.Unmarshal(buf, &header); err != nil {
+		return "", err
+	}
+	if header.XMLName.Local != "Return" || len(header.ReturnTypeCd) == 0 {
+		return "", utils.ErrUnknownReturnType
+	}
+	return irs_990.ReturnTypeCd(strings.TrimSpace(header.ReturnTypeCd)), nil
+}
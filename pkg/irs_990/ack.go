@@ -0,0 +1,312 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	// ErrUnknownXpath is given when an xpath doesn't match an element of the return
+	ErrUnknownXpath = errors.New("xpath doesn't match an element of the return")
+	// ErrMissingManifest is given when a file without manifest is added to an ack processor
+	ErrMissingManifest = errors.New("file hasn't a manifest with a submission id")
+)
+
+// Acceptance status values of the acknowledgements
+var (
+	AcceptedStatus = "Accepted"
+	RejectedStatus = "Rejected"
+)
+
+// Submission status values of the status records
+var (
+	AcceptedAckCreatedStatus = "Accepted Acknowledgement Created"
+	RejectedAckCreatedStatus = "Rejected Acknowledgement Created"
+	DeniedByIRSStatus        = "Denied by IRS"
+	DeniedByStateStatus      = "Denied by State"
+)
+
+var (
+	returnElement = "Return"
+	textStep      = "text()"
+)
+
+// FieldPath resolves an xpath of an acknowledgement, like /efile:Return/efile:ReturnData/efile:IRS990[1]/efile:TotalRevenueAmt,
+// to the Go field path of the element in a return, like ReturnData.IRS990.TotalRevenueAmt.
+// Namespace prefixes are ignored and the 1 based positions of the xpath become 0 based slice indexes.
+// The value is the value of the element in the return, empty when the return doesn't have the element.
+func (r *Return) FieldPath(xpath string) (path string, value string, err error) {
+	data := reflect.ValueOf(r).Elem()
+	present := true
+	var names []string
+
+	steps := strings.Split(strings.Trim(strings.TrimSpace(xpath), "/"), "/")
+	for index, step := range steps {
+		name, position, err := parseXpathStep(step)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %s", err, xpath)
+		}
+		if index == 0 && name == returnElement {
+			continue
+		}
+		if name == textStep {
+			break
+		}
+
+		field, ok := findElementField(data.Type(), name)
+		if !ok {
+			return "", "", fmt.Errorf("%w: %s", ErrUnknownXpath, xpath)
+		}
+		data = data.FieldByIndex(field.Index)
+		names = append(names, field.Name)
+
+		for data.Kind() == reflect.Ptr {
+			if data.IsNil() {
+				present = false
+				data = reflect.Zero(data.Type().Elem())
+				continue
+			}
+			data = data.Elem()
+		}
+		if data.Kind() == reflect.Slice {
+			if position == 0 {
+				position = 1
+			}
+			names[len(names)-1] += "[" + strconv.Itoa(position-1) + "]"
+			if position > data.Len() {
+				present = false
+				data = reflect.Zero(data.Type().Elem())
+			} else {
+				data = data.Index(position - 1)
+			}
+			for data.Kind() == reflect.Ptr {
+				if data.IsNil() {
+					present = false
+					data = reflect.Zero(data.Type().Elem())
+					continue
+				}
+				data = data.Elem()
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return "", "", fmt.Errorf("%w: %s", ErrUnknownXpath, xpath)
+	}
+	if present {
+		value = fieldValue(data)
+	}
+	return strings.Join(names, "."), value, nil
+}
+
+// parseXpathStep returns the element name and the position of a step, the position is 0 without predicate
+func parseXpathStep(step string) (string, int, error) {
+	position := 0
+	if open := strings.Index(step, "["); open >= 0 {
+		if !strings.HasSuffix(step, "]") {
+			return "", 0, ErrUnknownXpath
+		}
+		num, err := strconv.Atoi(step[open+1 : len(step)-1])
+		if err != nil || num < 1 {
+			return "", 0, ErrUnknownXpath
+		}
+		position = num
+		step = step[:open]
+	}
+
+	attr := strings.HasPrefix(step, "@")
+	step = strings.TrimPrefix(step, "@")
+	if colon := strings.Index(step, ":"); colon >= 0 {
+		step = step[colon+1:]
+	}
+	if len(step) == 0 {
+		return "", 0, ErrUnknownXpath
+	}
+	if attr {
+		step = "@" + step
+	}
+	return step, position, nil
+}
+
+// findElementField returns the struct field of the element, attribute names start with @
+func findElementField(parent reflect.Type, name string) (reflect.StructField, bool) {
+	if parent.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	attr := strings.HasPrefix(name, "@")
+	name = strings.TrimPrefix(name, "@")
+	for i := 0; i < parent.NumField(); i++ {
+		field := parent.Field(i)
+		element := utils.ElementName(field)
+		if space := strings.LastIndex(element, " "); space >= 0 {
+			element = element[space+1:]
+		}
+		if element != name {
+			continue
+		}
+		if attr == strings.Contains(field.Tag.Get("xml"), ",attr") {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldValue returns the value of an element as written in the xml document, empty for groups
+func fieldValue(data reflect.Value) string {
+	if data.CanInterface() {
+		if marshaler, ok := data.Interface().(encoding.TextMarshaler); ok {
+			if buf, err := marshaler.MarshalText(); err == nil {
+				return string(buf)
+			}
+		}
+	}
+	switch data.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map, reflect.Array, reflect.Interface:
+		return ""
+	}
+	return fmt.Sprint(data.Interface())
+}
+
+// FieldIssue is a validation error or alert of an acknowledgement with the field of the return it points at
+type FieldIssue struct {
+	Severity utils.Severity
+	RuleNum  string
+	Category string
+	Message  string
+	Xpath    string
+	// FieldPath is the Go field path in the return, empty when the xpath couldn't be resolved
+	FieldPath string
+	// FieldValue is the value of the field in the submitted return
+	FieldValue string
+	// ReportedValue is the value reported by the acknowledgement
+	ReportedValue string
+}
+
+func (r FieldIssue) String() string {
+	target := r.FieldPath
+	if len(target) == 0 {
+		target = r.Xpath
+	}
+	if len(target) == 0 {
+		target = "return"
+	}
+	value := r.FieldValue
+	if len(value) == 0 {
+		value = r.ReportedValue
+	}
+	if len(value) > 0 {
+		target = fmt.Sprintf("%s (%s)", target, value)
+	}
+	return fmt.Sprintf("%s: %s %s: %s", target, r.Severity, r.RuleNum, r.Message)
+}
+
+// AckReport is the acknowledgement of a submission resolved against the submitted return
+type AckReport struct {
+	Acknowledgement Acknowledgement
+	// File is the originating submission, nil when the processor doesn't know the submission id
+	File   *Irs990File
+	Errors []FieldIssue
+	Alerts []FieldIssue
+}
+
+// Accepted reports whether the submission was accepted
+func (r AckReport) Accepted() bool {
+	return r.Acknowledgement.AcceptanceStatusTxt == AcceptedStatus
+}
+
+func (r AckReport) String() string {
+	lines := []string{fmt.Sprintf("%s: %s", r.Acknowledgement.SubmissionId, r.Acknowledgement.AcceptanceStatusTxt)}
+	for _, issue := range r.Errors {
+		lines = append(lines, issue.String())
+	}
+	for _, issue := range r.Alerts {
+		lines = append(lines, issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AckProcessor matches acknowledgements to the submissions they acknowledge by submission id
+type AckProcessor struct {
+	mu    sync.RWMutex
+	files map[SubmissionIdType]*Irs990File
+}
+
+// NewAckProcessor returns an ack processor without submissions
+func NewAckProcessor() *AckProcessor {
+	return &AckProcessor{files: map[SubmissionIdType]*Irs990File{}}
+}
+
+// Add adds a transmitted submission, the submission id is taken from the manifest
+func (p *AckProcessor) Add(file *Irs990File) error {
+	if file == nil || file.Manifest == nil || len(file.Manifest.SubmissionId) == 0 {
+		return ErrMissingManifest
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.files[file.Manifest.SubmissionId] = file
+	return nil
+}
+
+// Process returns the report of each acknowledgement. The xpaths of unknown submissions
+// are resolved to field paths without field values.
+func (p *AckProcessor) Process(acks ...Acknowledgement) []AckReport {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	reports := make([]AckReport, 0, len(acks))
+	for _, ack := range acks {
+		report := AckReport{Acknowledgement: ack, File: p.files[ack.SubmissionId]}
+		ret := &Return{}
+		if report.File != nil {
+			ret = &report.File.XmlData
+		}
+
+		if ack.ValidationErrorList != nil {
+			for _, grp := range ack.ValidationErrorList.ValidationErrorGrp {
+				report.Errors = append(report.Errors, newFieldIssue(ret, grp.XpathContentTxt, FieldIssue{
+					Severity:      utils.Severity(grp.SeverityCd),
+					RuleNum:       grp.RuleNum,
+					Category:      grp.ErrorCategoryCd,
+					Message:       grp.ErrorMessageTxt,
+					ReportedValue: grp.FieldValueTxt,
+				}))
+			}
+		}
+		if ack.ValidationAlertList != nil {
+			for _, grp := range ack.ValidationAlertList.ValidationAlertGrp {
+				report.Alerts = append(report.Alerts, newFieldIssue(ret, grp.XpathContentTxt, FieldIssue{
+					Severity:      utils.Severity(grp.SeverityCd),
+					RuleNum:       grp.RuleNum,
+					Category:      grp.AlertCategoryCd,
+					Message:       grp.AlertMessageTxt,
+					ReportedValue: grp.FieldValueTxt,
+				}))
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+func newFieldIssue(ret *Return, xpath string, issue FieldIssue) FieldIssue {
+	issue.Xpath = xpath
+	if len(xpath) == 0 {
+		return issue
+	}
+	if path, value, err := ret.FieldPath(xpath); err == nil {
+		issue.FieldPath = path
+		issue.FieldValue = value
+	}
+	return issue
+}
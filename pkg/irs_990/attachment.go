@@ -0,0 +1,135 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+)
+
+var (
+	// ErrInvalidAttachmentName is given when an attachment file name doesn't follow the MeF file name rules
+	ErrInvalidAttachmentName = errors.New("attachment file name doesn't follow the MeF file name rules")
+	// ErrNotPDFAttachment is given when an attachment file isn't a PDF file
+	ErrNotPDFAttachment = errors.New("attachment file isn't a PDF file")
+	// ErrDuplicatedAttachment is given when an attachment file name is used by more than one attachment
+	ErrDuplicatedAttachment = errors.New("attachment file name is duplicated")
+	// ErrUnreferencedAttachment is given when an attachment file isn't referenced by a binary attachment
+	ErrUnreferencedAttachment = errors.New("attachment file isn't referenced by a binary attachment")
+)
+
+var (
+	attachmentZipDir = "attachment"
+	// MeF file names use letters, digits, underscore, hyphen and period, at most 64 characters
+	maxAttachmentNameLen = 64
+	attachmentNameRegex  = regexp.MustCompile(`^[A-Za-z0-9_\-.]+\.pdf$`)
+	pdfSignature         = []byte("%PDF-")
+	pdfDocumentTypeCd    = DocumentTypeCd("PDF")
+)
+
+// Attachment is a binary attachment file of the submission zip, written to the attachment directory
+type Attachment struct {
+	Name string
+	Data []byte
+}
+
+// ZipFile returns the file name of the attachment in the submission zip
+func (r Attachment) ZipFile() string {
+	return path.Join(attachmentZipDir, r.Name)
+}
+
+// Validate checks the file name rules and that the attachment is a PDF file
+func (r Attachment) Validate() error {
+	if len(r.Name) > maxAttachmentNameLen || !attachmentNameRegex.MatchString(r.Name) {
+		return fmt.Errorf("%w: %q", ErrInvalidAttachmentName, r.Name)
+	}
+	if !bytes.HasPrefix(r.Data, pdfSignature) {
+		return fmt.Errorf("%w: %s", ErrNotPDFAttachment, r.Name)
+	}
+	return nil
+}
+
+// AddAttachment adds the attachment file and its binary attachment to the return.
+// The attachment location and the PDF document type are set, the document and
+// binary attachment counts are updated.
+func (r *Irs990File) AddAttachment(attachment Attachment, binary BinaryAttachment) error {
+	if err := attachment.Validate(); err != nil {
+		return err
+	}
+	for _, existing := range r.Attachments {
+		if existing.Name == attachment.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicatedAttachment, attachment.Name)
+		}
+	}
+
+	binary.AttachmentLocationTxt = attachment.ZipFile()
+	if len(binary.DocumentTypeCd) == 0 {
+		binary.DocumentTypeCd = pdfDocumentTypeCd
+	}
+	r.Attachments = append(r.Attachments, attachment)
+	r.XmlData.ReturnData.BinaryAttachment = append(r.XmlData.ReturnData.BinaryAttachment, binary)
+	r.XmlData.FixConsistency()
+	return nil
+}
+
+// RemoveAttachment removes the attachment file and the binary attachments referencing it,
+// the document and binary attachment counts are updated
+func (r *Irs990File) RemoveAttachment(name string) {
+	var attachments []Attachment
+	for _, attachment := range r.Attachments {
+		if attachment.Name != name {
+			attachments = append(attachments, attachment)
+		}
+	}
+	r.Attachments = attachments
+
+	location := Attachment{Name: name}.ZipFile()
+	var binaries []BinaryAttachment
+	for _, binary := range r.XmlData.ReturnData.BinaryAttachment {
+		if path.Clean(binary.AttachmentLocationTxt) != location {
+			binaries = append(binaries, binary)
+		}
+	}
+	r.XmlData.ReturnData.BinaryAttachment = binaries
+	r.XmlData.FixConsistency()
+}
+
+// validateAttachments checks every attachment file and that binary attachments reference them,
+// references to missing files are found by ValidateAttachmentLocations
+func (r Irs990File) validateAttachments() error {
+	if errs := r.attachmentErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// attachmentErrors checks every attachment file, an error per invalid, repeated or unreferenced file
+func (r Irs990File) attachmentErrors() []error {
+	referenced := make(map[string]bool, len(r.XmlData.ReturnData.BinaryAttachment))
+	for _, binary := range r.XmlData.ReturnData.BinaryAttachment {
+		referenced[path.Clean(binary.AttachmentLocationTxt)] = true
+	}
+
+	var errs []error
+	names := make(map[string]bool, len(r.Attachments))
+	for _, attachment := range r.Attachments {
+		if err := attachment.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if names[attachment.Name] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrDuplicatedAttachment, attachment.Name))
+			continue
+		}
+		names[attachment.Name] = true
+		if !referenced[attachment.ZipFile()] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnreferencedAttachment, attachment.Name))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	routingNumberPattern = regexp.MustCompile(`^(0[1-9]|1[0-2]|2[1-9]|3[0-2])[0-9]{7}$`)
+	bankAccountPattern   = regexp.MustCompile(`^[A-Za-z0-9\-]+$`)
+)
+
+var (
+	// Weights of the ABA routing number check digit (3-7-1)
+	routingNumberWeights = []int{3, 7, 1, 3, 7, 1, 3, 7, 1}
+	bankAccountMaxLength = 17
+)
+
+// IsValidRoutingNumber reports whether routing is a valid ABA routing transit number.
+// The first two digits must be 01 through 12 or 21 through 32, and the weighted
+// sum of the digits (3, 7, 1, 3, 7, 1, 3, 7, 1) must be a multiple of 10.
+func IsValidRoutingNumber(routing string) bool {
+	if !routingNumberPattern.MatchString(routing) {
+		return false
+	}
+
+	sum := 0
+	for i, c := range routing {
+		sum += int(c-'0') * routingNumberWeights[i]
+	}
+	return sum%10 == 0
+}
+
+// IsValidBankAccountNumber reports whether account is no more than 17 letters, digits and hyphens
+// and isn't all zeros
+func IsValidBankAccountNumber(account string) bool {
+	if len(account) > bankAccountMaxLength || !bankAccountPattern.MatchString(account) {
+		return false
+	}
+	return strings.Trim(account, "0-") != ""
+}
+
+// ValidateDirectDebit checks the bank account of a direct debit (electronic funds withdrawal) payment.
+// All of routing number, account number and account type are required.
+func ValidateDirectDebit(routing RoutingTransitNumberType, account BankAccountNumberType, accountType BankAccountType) error {
+	if len(routing) == 0 || len(account) == 0 || len(accountType) == 0 {
+		return errors.New("direct debit requires routing number, bank account number and bank account type")
+	}
+	if err := routing.Validate(); err != nil {
+		return err
+	}
+	if err := account.Validate(); err != nil {
+		return err
+	}
+	return accountType.Validate()
+}
+
+// IRSPayment is the payment record of a direct debit, the IRSPayment document of the efile schema.
+// The 990 return has no payment, the record is filed with the returns that have a balance due.
+type IRSPayment struct {
+	RoutingTransitNum       RoutingTransitNumberType `xml:"RoutingTransitNum"`
+	BankAccountNum          BankAccountNumberType    `xml:"BankAccountNum"`
+	BankAccountTypeCd       BankAccountType          `xml:"BankAccountTypeCd"`
+	PaymentAmt              int                      `xml:"PaymentAmt"`
+	RequestedPaymentDt      DateType                 `xml:"RequestedPaymentDt"`
+	TaxpayerDaytimePhoneNum PhoneNumberType          `xml:"TaxpayerDaytimePhoneNum"`
+	DocumentId              IdType                   `xml:"documentId,attr"`
+	SoftwareId              *SoftwareIdType          `xml:"softwareId,attr,omitempty" json:",omitempty"`
+	SoftwareVersionNum      string                   `xml:"softwareVersionNum,attr,omitempty" json:",omitempty"`
+	DocumentName            string                   `xml:"documentName,attr,omitempty" json:",omitempty"`
+}
+
+func (r IRSPayment) Validate() error {
+	if err := ValidateDirectDebit(r.RoutingTransitNum, r.BankAccountNum, r.BankAccountTypeCd); err != nil {
+		return err
+	}
+	return utils.Validate(&r)
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/codelist"
+)
+
+var (
+	// ErrCodeNotInTaxYr is given when a country or state code isn't in the code list of the tax year
+	ErrCodeNotInTaxYr = errors.New("code isn't in the code list of the tax year")
+)
+
+// ValidateCodeLists checks the country and state codes of the return against the code list of its tax year.
+// The code types accept the codes of every tax year on their own.
+func (r *Return) ValidateCodeLists() error {
+	year := time.Time(r.ReturnHeader.TaxYr).Year()
+	return validateCodeLists(reflect.ValueOf(r).Elem(), codelist.ForYear(year), year)
+}
+
+func validateCodeLists(data reflect.Value, list *codelist.CodeList, year int) error {
+	switch data.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if data.IsNil() {
+			return nil
+		}
+		return validateCodeLists(data.Elem(), list, year)
+	case reflect.Slice:
+		for i := 0; i < data.Len(); i++ {
+			if err := validateCodeLists(data.Index(i), list, year); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		for i := 0; i < data.NumField(); i++ {
+			if !data.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := validateCodeLists(data.Field(i), list, year); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !data.CanInterface() {
+		return nil
+	}
+	switch code := data.Interface().(type) {
+	case CountryType:
+		if len(code) > 0 && !list.IsCountry(string(code)) {
+			return fmt.Errorf("%w: country %s in tax year %d", ErrCodeNotInTaxYr, code, year)
+		}
+	case AllCountriesType:
+		if len(code) > 0 && code != "US" && !list.IsCountry(string(code)) {
+			return fmt.Errorf("%w: country %s in tax year %d", ErrCodeNotInTaxYr, code, year)
+		}
+	case StateType:
+		if len(code) > 0 && !list.IsState(string(code)) {
+			return fmt.Errorf("%w: state %s in tax year %d", ErrCodeNotInTaxYr, code, year)
+		}
+	}
+	return nil
+}
@@ -49,8 +49,8 @@ func (r AdjustedNetIncomeGrp) Validate() error {
 // Content model for affiliate
 type AffiliateListingGrpType struct {
 	BusinessName           *BusinessNameType       `xml:"BusinessName,omitempty" json:",omitempty"`
-	USAddress              USAddressType           `xml:"USAddress"`
-	ForeignAddress         ForeignAddressType      `xml:"ForeignAddress"`
+	USAddress              *USAddressType          `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress         *ForeignAddressType     `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	EIN                    EINType                 `xml:"EIN"`
 	BusinessNameControlTxt BusinessNameControlType `xml:"BusinessNameControlTxt"`
 }
@@ -61,8 +61,8 @@ func (r AffiliateListingGrpType) Validate() error {
 
 type AffiliatedScheduleGrp struct {
 	BusinessName                  *BusinessNameType   `xml:"BusinessName,omitempty" json:",omitempty"`
-	USAddress                     *USAddressType      `xml:"USAddress,omitempty" json:",omitempty"`
-	ForeignAddress                *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty"`
+	USAddress                     *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	EIN                           EINType             `xml:"EIN"`
 	ElectingOrganizationInd       CheckboxType        `xml:"ElectingOrganizationInd,omitempty" json:",omitempty"`
 	TotalGrassrootsLobbyAmt       int                 `xml:"TotalGrassrootsLobbyAmt"`
@@ -102,11 +102,11 @@ func (r AuditedFinancialStmtAttInd) Validate() error {
 }
 
 type BooksInCareOfDetail struct {
-	PersonNm       PersonNameType     `xml:"PersonNm"`
-	BusinessName   BusinessNameType   `xml:"BusinessName"`
-	PhoneNum       PhoneNumberType    `xml:"PhoneNum"`
-	USAddress      USAddressType      `xml:"USAddress"`
-	ForeignAddress ForeignAddressType `xml:"ForeignAddress"`
+	PersonNm       PersonNameType      `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName   *BusinessNameType   `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	PhoneNum       PhoneNumberType     `xml:"PhoneNum"`
+	USAddress      *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 }
 
 func (r BooksInCareOfDetail) Validate() error {
@@ -179,15 +179,15 @@ func (r BusinessRlnWithOrgMemInd) Validate() error {
 }
 
 type CharitableContributionsDetailType struct {
-	ContributorNum                 int                `xml:"ContributorNum"`
-	GiftPurposeTxt                 string             `xml:"GiftPurposeTxt"`
-	GiftUseTxt                     string             `xml:"GiftUseTxt"`
-	HowGiftIsHeldDesc              string             `xml:"HowGiftIsHeldDesc,omitempty" json:",omitempty"`
-	TransfereeNameBusiness         BusinessNameType   `xml:"TransfereeNameBusiness"`
-	TransfereeNameIndividual       PersonNameType     `xml:"TransfereeNameIndividual"`
-	TransfereeUSAddress            USAddressType      `xml:"TransfereeUSAddress"`
-	TransfereeForeignAddress       ForeignAddressType `xml:"TransfereeForeignAddress"`
-	RlnOfTransferorToTransfereeTxt string             `xml:"RlnOfTransferorToTransfereeTxt,omitempty" json:",omitempty"`
+	ContributorNum                 int                 `xml:"ContributorNum"`
+	GiftPurposeTxt                 string              `xml:"GiftPurposeTxt"`
+	GiftUseTxt                     string              `xml:"GiftUseTxt"`
+	HowGiftIsHeldDesc              string              `xml:"HowGiftIsHeldDesc,omitempty" json:",omitempty"`
+	TransfereeNameBusiness         *BusinessNameType   `xml:"TransfereeNameBusiness,omitempty" json:",omitempty" choice:"TransfereeNameBusiness"`
+	TransfereeNameIndividual       PersonNameType      `xml:"TransfereeNameIndividual,omitempty" json:",omitempty" choice:"TransfereeNameBusiness"`
+	TransfereeUSAddress            *USAddressType      `xml:"TransfereeUSAddress,omitempty" json:",omitempty" choice:"TransfereeUSAddress"`
+	TransfereeForeignAddress       *ForeignAddressType `xml:"TransfereeForeignAddress,omitempty" json:",omitempty" choice:"TransfereeUSAddress"`
+	RlnOfTransferorToTransfereeTxt string              `xml:"RlnOfTransferorToTransfereeTxt,omitempty" json:",omitempty"`
 }
 
 func (r CharitableContributionsDetailType) Validate() error {
@@ -234,8 +234,8 @@ func (r ConsolidatedAuditFinclStmtInd) Validate() error {
 }
 
 type ContractorAddress struct {
-	USAddress      USAddressType      `xml:"USAddress"`
-	ForeignAddress ForeignAddressType `xml:"ForeignAddress"`
+	USAddress      *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 }
 
 func (r ContractorAddress) Validate() error {
@@ -243,8 +243,8 @@ func (r ContractorAddress) Validate() error {
 }
 
 type ContractorName struct {
-	PersonNm     PersonNameType   `xml:"PersonNm"`
-	BusinessName BusinessNameType `xml:"BusinessName"`
+	PersonNm     PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
 }
 
 func (r ContractorName) Validate() error {
@@ -252,16 +252,16 @@ func (r ContractorName) Validate() error {
 }
 
 type ContributorInformationGrpType struct {
-	ContributorNum            int                `xml:"ContributorNum"`
-	ContributorBusinessName   BusinessNameType   `xml:"ContributorBusinessName"`
-	ContributorPersonNm       PersonNameType     `xml:"ContributorPersonNm"`
-	Paid527j1Ind              CheckboxType       `xml:"Paid527j1Ind"`
-	ContributorUSAddress      USAddressType      `xml:"ContributorUSAddress"`
-	ContributorForeignAddress ForeignAddressType `xml:"ContributorForeignAddress"`
-	TotalContributionsAmt     int                `xml:"TotalContributionsAmt"`
-	PersonContributionInd     CheckboxType       `xml:"PersonContributionInd,omitempty" json:",omitempty"`
-	PayrollContributionInd    CheckboxType       `xml:"PayrollContributionInd,omitempty" json:",omitempty"`
-	NoncashContributionInd    CheckboxType       `xml:"NoncashContributionInd,omitempty" json:",omitempty"`
+	ContributorNum            int                 `xml:"ContributorNum"`
+	ContributorBusinessName   *BusinessNameType   `xml:"ContributorBusinessName,omitempty" json:",omitempty" choice:"ContributorBusinessName"`
+	ContributorPersonNm       PersonNameType      `xml:"ContributorPersonNm,omitempty" json:",omitempty" choice:"ContributorBusinessName"`
+	Paid527j1Ind              CheckboxType        `xml:"Paid527j1Ind,omitempty" json:",omitempty" choice:"ContributorBusinessName"`
+	ContributorUSAddress      *USAddressType      `xml:"ContributorUSAddress,omitempty" json:",omitempty" choice:"ContributorUSAddress"`
+	ContributorForeignAddress *ForeignAddressType `xml:"ContributorForeignAddress,omitempty" json:",omitempty" choice:"ContributorUSAddress"`
+	TotalContributionsAmt     int                 `xml:"TotalContributionsAmt"`
+	PersonContributionInd     CheckboxType        `xml:"PersonContributionInd,omitempty" json:",omitempty"`
+	PayrollContributionInd    CheckboxType        `xml:"PayrollContributionInd,omitempty" json:",omitempty"`
+	NoncashContributionInd    CheckboxType        `xml:"NoncashContributionInd,omitempty" json:",omitempty"`
 }
 
 func (r ContributorInformationGrpType) Validate() error {
@@ -269,9 +269,9 @@ func (r ContributorInformationGrpType) Validate() error {
 }
 
 type CostingMethodologyUsedGrp struct {
-	CostAccountingSystemInd CheckboxType `xml:"CostAccountingSystemInd"`
-	CostToChargeRatioInd    CheckboxType `xml:"CostToChargeRatioInd"`
-	OtherInd                CheckboxType `xml:"OtherInd"`
+	CostAccountingSystemInd CheckboxType `xml:"CostAccountingSystemInd,omitempty" json:",omitempty" choice:"CostAccountingSystemInd"`
+	CostToChargeRatioInd    CheckboxType `xml:"CostToChargeRatioInd,omitempty" json:",omitempty" choice:"CostAccountingSystemInd"`
+	OtherInd                CheckboxType `xml:"OtherInd,omitempty" json:",omitempty" choice:"CostAccountingSystemInd"`
 }
 
 func (r CostingMethodologyUsedGrp) Validate() error {
@@ -328,11 +328,11 @@ func (r DiscountedCareOthPercentageGrp) Validate() error {
 }
 
 type DisqualifiedPersonExBnftTrGrpType struct {
-	PersonNm                    PersonNameType   `xml:"PersonNm"`
-	BusinessName                BusinessNameType `xml:"BusinessName"`
-	RlnDisqualifiedPersonOrgTxt string           `xml:"RlnDisqualifiedPersonOrgTxt,omitempty" json:",omitempty"`
-	TransactionDesc             string           `xml:"TransactionDesc,omitempty" json:",omitempty"`
-	TransactionCorrectedInd     bool             `xml:"TransactionCorrectedInd,omitempty" json:",omitempty"`
+	PersonNm                    PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName                *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	RlnDisqualifiedPersonOrgTxt string            `xml:"RlnDisqualifiedPersonOrgTxt,omitempty" json:",omitempty"`
+	TransactionDesc             string            `xml:"TransactionDesc,omitempty" json:",omitempty"`
+	TransactionCorrectedInd     bool              `xml:"TransactionCorrectedInd,omitempty" json:",omitempty"`
 }
 
 func (r DisqualifiedPersonExBnftTrGrpType) Validate() error {
@@ -440,8 +440,8 @@ type Filer struct {
 	InCareOfNm             *InCareOfNameType       `xml:"InCareOfNm,omitempty" json:",omitempty"`
 	BusinessNameControlTxt BusinessNameControlType `xml:"BusinessNameControlTxt"`
 	PhoneNum               *PhoneNumberType        `xml:"PhoneNum,omitempty" json:",omitempty"`
-	USAddress              USAddressType           `xml:"USAddress"`
-	ForeignAddress         ForeignAddressType      `xml:"ForeignAddress"`
+	USAddress              *USAddressType          `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress         *ForeignAddressType     `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 }
 
 func (r Filer) Validate() error {
@@ -449,9 +449,9 @@ func (r Filer) Validate() error {
 }
 
 type FinancialStatementType struct {
-	SeparateBasisFinclStmtInd     CheckboxType `xml:"SeparateBasisFinclStmtInd"`
-	ConsolidatedBasisFinclStmtInd CheckboxType `xml:"ConsolidatedBasisFinclStmtInd"`
-	ConsolAndSepBasisFinclStmtInd CheckboxType `xml:"ConsolAndSepBasisFinclStmtInd"`
+	SeparateBasisFinclStmtInd     CheckboxType `xml:"SeparateBasisFinclStmtInd,omitempty" json:",omitempty" choice:"SeparateBasisFinclStmtInd"`
+	ConsolidatedBasisFinclStmtInd CheckboxType `xml:"ConsolidatedBasisFinclStmtInd,omitempty" json:",omitempty" choice:"SeparateBasisFinclStmtInd"`
+	ConsolAndSepBasisFinclStmtInd CheckboxType `xml:"ConsolAndSepBasisFinclStmtInd,omitempty" json:",omitempty" choice:"SeparateBasisFinclStmtInd"`
 }
 
 func (r FinancialStatementType) Validate() error {
@@ -643,20 +643,20 @@ func (r Form990PartVIIIGroup7Type) Validate() error {
 }
 
 type Form990PartVIISectionAGrp struct {
-	PersonNm                       PersonNameType   `xml:"PersonNm"`
-	BusinessName                   BusinessNameType `xml:"BusinessName"`
-	TitleTxt                       string           `xml:"TitleTxt,omitempty" json:",omitempty"`
-	AverageHoursPerWeekRt          float64          `xml:"AverageHoursPerWeekRt,omitempty" json:",omitempty"`
-	AverageHoursPerWeekRltdOrgRt   float64          `xml:"AverageHoursPerWeekRltdOrgRt,omitempty" json:",omitempty"`
-	IndividualTrusteeOrDirectorInd CheckboxType     `xml:"IndividualTrusteeOrDirectorInd,omitempty" json:",omitempty"`
-	InstitutionalTrusteeInd        CheckboxType     `xml:"InstitutionalTrusteeInd,omitempty" json:",omitempty"`
-	OfficerInd                     CheckboxType     `xml:"OfficerInd,omitempty" json:",omitempty"`
-	KeyEmployeeInd                 CheckboxType     `xml:"KeyEmployeeInd,omitempty" json:",omitempty"`
-	HighestCompensatedEmployeeInd  CheckboxType     `xml:"HighestCompensatedEmployeeInd,omitempty" json:",omitempty"`
-	FormerOfcrDirectorTrusteeInd   CheckboxType     `xml:"FormerOfcrDirectorTrusteeInd,omitempty" json:",omitempty"`
-	ReportableCompFromOrgAmt       int              `xml:"ReportableCompFromOrgAmt,omitempty" json:",omitempty"`
-	ReportableCompFromRltdOrgAmt   int              `xml:"ReportableCompFromRltdOrgAmt,omitempty" json:",omitempty"`
-	OtherCompensationAmt           int              `xml:"OtherCompensationAmt,omitempty" json:",omitempty"`
+	PersonNm                       PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName                   *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	TitleTxt                       string            `xml:"TitleTxt,omitempty" json:",omitempty"`
+	AverageHoursPerWeekRt          float64           `xml:"AverageHoursPerWeekRt,omitempty" json:",omitempty"`
+	AverageHoursPerWeekRltdOrgRt   float64           `xml:"AverageHoursPerWeekRltdOrgRt,omitempty" json:",omitempty"`
+	IndividualTrusteeOrDirectorInd CheckboxType      `xml:"IndividualTrusteeOrDirectorInd,omitempty" json:",omitempty"`
+	InstitutionalTrusteeInd        CheckboxType      `xml:"InstitutionalTrusteeInd,omitempty" json:",omitempty"`
+	OfficerInd                     CheckboxType      `xml:"OfficerInd,omitempty" json:",omitempty"`
+	KeyEmployeeInd                 CheckboxType      `xml:"KeyEmployeeInd,omitempty" json:",omitempty"`
+	HighestCompensatedEmployeeInd  CheckboxType      `xml:"HighestCompensatedEmployeeInd,omitempty" json:",omitempty"`
+	FormerOfcrDirectorTrusteeInd   CheckboxType      `xml:"FormerOfcrDirectorTrusteeInd,omitempty" json:",omitempty"`
+	ReportableCompFromOrgAmt       int               `xml:"ReportableCompFromOrgAmt,omitempty" json:",omitempty"`
+	ReportableCompFromRltdOrgAmt   int               `xml:"ReportableCompFromRltdOrgAmt,omitempty" json:",omitempty"`
+	OtherCompensationAmt           int               `xml:"OtherCompensationAmt,omitempty" json:",omitempty"`
 }
 
 func (r Form990PartVIISectionAGrp) Validate() error {
@@ -946,16 +946,16 @@ func (r Form990SchMGroup3Type) Validate() error {
 }
 
 type Form990SchNGroup1Type struct {
-	AssetsDistriOrExpnssPaidDesc string             `xml:"AssetsDistriOrExpnssPaidDesc,omitempty" json:",omitempty"`
-	DistributionDt               DateType           `xml:"DistributionDt,omitempty" json:",omitempty"`
-	FairMarketValueOfAssetAmt    int                `xml:"FairMarketValueOfAssetAmt,omitempty" json:",omitempty"`
-	MethodOfFMVDeterminationTxt  string             `xml:"MethodOfFMVDeterminationTxt,omitempty" json:",omitempty"`
-	EIN                          EINType            `xml:"EIN,omitempty" json:",omitempty"`
-	PersonNm                     PersonNameType     `xml:"PersonNm"`
-	BusinessName                 BusinessNameType   `xml:"BusinessName"`
-	USAddress                    USAddressType      `xml:"USAddress"`
-	ForeignAddress               ForeignAddressType `xml:"ForeignAddress"`
-	IRCSectionTxt                string             `xml:"IRCSectionTxt,omitempty" json:",omitempty"`
+	AssetsDistriOrExpnssPaidDesc string              `xml:"AssetsDistriOrExpnssPaidDesc,omitempty" json:",omitempty"`
+	DistributionDt               *DateType           `xml:"DistributionDt,omitempty" json:",omitempty"`
+	FairMarketValueOfAssetAmt    int                 `xml:"FairMarketValueOfAssetAmt,omitempty" json:",omitempty"`
+	MethodOfFMVDeterminationTxt  string              `xml:"MethodOfFMVDeterminationTxt,omitempty" json:",omitempty"`
+	EIN                          EINType             `xml:"EIN,omitempty" json:",omitempty"`
+	PersonNm                     PersonNameType      `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName                 *BusinessNameType   `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	USAddress                    *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress               *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	IRCSectionTxt                string              `xml:"IRCSectionTxt,omitempty" json:",omitempty"`
 }
 
 func (r Form990SchNGroup1Type) Validate() error {
@@ -981,15 +981,15 @@ func (r FreeCareOthPercentageGrp) Validate() error {
 }
 
 type FundraiserActivityInfoGrpType struct {
-	PersonNm                    PersonNameType     `xml:"PersonNm"`
-	OrganizationBusinessName    BusinessNameType   `xml:"OrganizationBusinessName"`
-	USAddress                   USAddressType      `xml:"USAddress"`
-	ForeignAddress              ForeignAddressType `xml:"ForeignAddress"`
-	ActivityTxt                 string             `xml:"ActivityTxt,omitempty" json:",omitempty"`
-	FundraiserControlOfFundsInd bool               `xml:"FundraiserControlOfFundsInd,omitempty" json:",omitempty"`
-	GrossReceiptsAmt            int                `xml:"GrossReceiptsAmt,omitempty" json:",omitempty"`
-	RetainedByContractorAmt     int                `xml:"RetainedByContractorAmt,omitempty" json:",omitempty"`
-	NetToOrganizationAmt        int                `xml:"NetToOrganizationAmt,omitempty" json:",omitempty"`
+	PersonNm                    PersonNameType      `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	OrganizationBusinessName    *BusinessNameType   `xml:"OrganizationBusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	USAddress                   *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress              *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ActivityTxt                 string              `xml:"ActivityTxt,omitempty" json:",omitempty"`
+	FundraiserControlOfFundsInd bool                `xml:"FundraiserControlOfFundsInd,omitempty" json:",omitempty"`
+	GrossReceiptsAmt            int                 `xml:"GrossReceiptsAmt,omitempty" json:",omitempty"`
+	RetainedByContractorAmt     int                 `xml:"RetainedByContractorAmt,omitempty" json:",omitempty"`
+	NetToOrganizationAmt        int                 `xml:"NetToOrganizationAmt,omitempty" json:",omitempty"`
 }
 
 func (r FundraiserActivityInfoGrpType) Validate() error {
@@ -1157,12 +1157,12 @@ func (r GrantsToOrganizationsInd) Validate() error {
 }
 
 type GrntAsstBnftInterestedPrsnGrp struct {
-	PersonNm               PersonNameType   `xml:"PersonNm"`
-	BusinessName           BusinessNameType `xml:"BusinessName"`
-	RelationshipWithOrgTxt string           `xml:"RelationshipWithOrgTxt,omitempty" json:",omitempty"`
-	CashGrantAmt           int              `xml:"CashGrantAmt,omitempty" json:",omitempty"`
-	TypeOfAssistanceTxt    string           `xml:"TypeOfAssistanceTxt,omitempty" json:",omitempty"`
-	AssistancePurposeTxt   string           `xml:"AssistancePurposeTxt,omitempty" json:",omitempty"`
+	PersonNm               PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName           *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	RelationshipWithOrgTxt string            `xml:"RelationshipWithOrgTxt,omitempty" json:",omitempty"`
+	CashGrantAmt           int               `xml:"CashGrantAmt,omitempty" json:",omitempty"`
+	TypeOfAssistanceTxt    string            `xml:"TypeOfAssistanceTxt,omitempty" json:",omitempty"`
+	AssistancePurposeTxt   string            `xml:"AssistancePurposeTxt,omitempty" json:",omitempty"`
 }
 
 func (r GrntAsstBnftInterestedPrsnGrp) Validate() error {
@@ -1224,7 +1224,7 @@ type HospitalFcltyPoliciesPrctcGrp struct {
 	ImplementationStrategyAdptYr   ImplementationStrategyAdptYr `xml:"ImplementationStrategyAdptYr,omitempty" json:",omitempty"`
 	StrategyPostedWebsiteInd       bool                         `xml:"StrategyPostedWebsiteInd,omitempty" json:",omitempty"`
 	StrategyWebsiteURLTxt          string                       `xml:"StrategyWebsiteURLTxt,omitempty" json:",omitempty"`
-	StrategyAttachedInd            StrategyAttachedInd          `xml:"StrategyAttachedInd,omitempty" json:",omitempty"`
+	StrategyAttachedInd            *StrategyAttachedInd         `xml:"StrategyAttachedInd,omitempty" json:",omitempty"`
 	OrganizationIncurExciseTaxInd  bool                         `xml:"OrganizationIncurExciseTaxInd,omitempty" json:",omitempty"`
 	Form4720FiledInd               bool                         `xml:"Form4720FiledInd,omitempty" json:",omitempty"`
 	ExciseReportForm4720ForAllAmt  int                          `xml:"ExciseReportForm4720ForAllAmt,omitempty" json:",omitempty"`
@@ -1296,8 +1296,8 @@ func (r HospitalFcltyPoliciesPrctcGrp) Validate() error {
 type HospitalNameAndAddressGrpType struct {
 	SupportedOrganizationName *BusinessNameType `xml:"SupportedOrganizationName,omitempty" json:",omitempty"`
 	CityNm                    CityType          `xml:"CityNm"`
-	StateAbbreviationCd       StateType         `xml:"StateAbbreviationCd"`
-	CountryCd                 CountryType       `xml:"CountryCd"`
+	StateAbbreviationCd       StateType         `xml:"StateAbbreviationCd,omitempty" json:",omitempty" choice:"StateAbbreviationCd"`
+	CountryCd                 CountryType       `xml:"CountryCd,omitempty" json:",omitempty" choice:"StateAbbreviationCd"`
 }
 
 func (r HospitalNameAndAddressGrpType) Validate() error {
@@ -1306,8 +1306,8 @@ func (r HospitalNameAndAddressGrpType) Validate() error {
 
 // IP address type to include either decimal or hexi decimal format
 type IPAddressType struct {
-	IPv4AddressTxt IPv4Type `xml:"IPv4AddressTxt"`
-	IPv6AddressTxt IPv6Type `xml:"IPv6AddressTxt"`
+	IPv4AddressTxt IPv4Type `xml:"IPv4AddressTxt,omitempty" json:",omitempty" choice:"IPv4AddressTxt"`
+	IPv6AddressTxt IPv6Type `xml:"IPv6AddressTxt,omitempty" json:",omitempty" choice:"IPv4AddressTxt"`
 }
 
 func (r IPAddressType) Validate() error {
@@ -1316,16 +1316,16 @@ func (r IPAddressType) Validate() error {
 
 type IdDisregardedEntitiesGrp struct {
 	DisregardedEntityName         *BusinessNameType     `xml:"DisregardedEntityName,omitempty" json:",omitempty"`
-	USAddress                     USAddressType         `xml:"USAddress"`
-	ForeignAddress                ForeignAddressType    `xml:"ForeignAddress"`
+	USAddress                     *USAddressType        `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                *ForeignAddressType   `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	EIN                           EINType               `xml:"EIN,omitempty" json:",omitempty"`
 	PrimaryActivitiesTxt          string                `xml:"PrimaryActivitiesTxt,omitempty" json:",omitempty"`
-	LegalDomicileStateCd          StateType             `xml:"LegalDomicileStateCd"`
-	LegalDomicileForeignCountryCd CountryType           `xml:"LegalDomicileForeignCountryCd"`
+	LegalDomicileStateCd          StateType             `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileForeignCountryCd CountryType           `xml:"LegalDomicileForeignCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
 	TotalIncomeAmt                int                   `xml:"TotalIncomeAmt,omitempty" json:",omitempty"`
 	EndOfYearAssetsAmt            int                   `xml:"EndOfYearAssetsAmt,omitempty" json:",omitempty"`
-	DirectControllingEntityName   BusinessNameType      `xml:"DirectControllingEntityName"`
-	DirectControllingNACd         DirectControllingNACd `xml:"DirectControllingNACd"`
+	DirectControllingEntityName   *BusinessNameType     `xml:"DirectControllingEntityName,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
+	DirectControllingNACd         DirectControllingNACd `xml:"DirectControllingNACd,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
 }
 
 func (r IdDisregardedEntitiesGrp) Validate() error {
@@ -1334,14 +1334,14 @@ func (r IdDisregardedEntitiesGrp) Validate() error {
 
 type IdRelatedOrgTxblCorpTrGrp struct {
 	RelatedOrganizationName       *BusinessNameType     `xml:"RelatedOrganizationName,omitempty" json:",omitempty"`
-	USAddress                     USAddressType         `xml:"USAddress"`
-	ForeignAddress                ForeignAddressType    `xml:"ForeignAddress"`
+	USAddress                     *USAddressType        `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                *ForeignAddressType   `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	EIN                           EINType               `xml:"EIN,omitempty" json:",omitempty"`
 	PrimaryActivitiesTxt          string                `xml:"PrimaryActivitiesTxt,omitempty" json:",omitempty"`
-	LegalDomicileStateCd          StateType             `xml:"LegalDomicileStateCd"`
-	LegalDomicileForeignCountryCd CountryType           `xml:"LegalDomicileForeignCountryCd"`
-	DirectControllingEntityName   BusinessNameType      `xml:"DirectControllingEntityName"`
-	DirectControllingNACd         DirectControllingNACd `xml:"DirectControllingNACd"`
+	LegalDomicileStateCd          StateType             `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileForeignCountryCd CountryType           `xml:"LegalDomicileForeignCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	DirectControllingEntityName   *BusinessNameType     `xml:"DirectControllingEntityName,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
+	DirectControllingNACd         DirectControllingNACd `xml:"DirectControllingNACd,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
 	EntityTypeTxt                 string                `xml:"EntityTypeTxt,omitempty" json:",omitempty"`
 	ShareOfTotalIncomeAmt         int                   `xml:"ShareOfTotalIncomeAmt,omitempty" json:",omitempty"`
 	ShareOfEOYAssetsAmt           int                   `xml:"ShareOfEOYAssetsAmt,omitempty" json:",omitempty"`
@@ -1355,14 +1355,14 @@ func (r IdRelatedOrgTxblCorpTrGrp) Validate() error {
 
 type IdRelatedOrgTxblPartnershipGrp struct {
 	RelatedOrganizationName        *BusinessNameType     `xml:"RelatedOrganizationName,omitempty" json:",omitempty"`
-	USAddress                      USAddressType         `xml:"USAddress"`
-	ForeignAddress                 ForeignAddressType    `xml:"ForeignAddress"`
+	USAddress                      *USAddressType        `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                 *ForeignAddressType   `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	EIN                            EINType               `xml:"EIN,omitempty" json:",omitempty"`
 	PrimaryActivitiesTxt           string                `xml:"PrimaryActivitiesTxt,omitempty" json:",omitempty"`
-	LegalDomicileStateCd           StateType             `xml:"LegalDomicileStateCd"`
-	LegalDomicileForeignCountryCd  CountryType           `xml:"LegalDomicileForeignCountryCd"`
-	DirectControllingEntityName    BusinessNameType      `xml:"DirectControllingEntityName"`
-	DirectControllingNACd          DirectControllingNACd `xml:"DirectControllingNACd"`
+	LegalDomicileStateCd           StateType             `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileForeignCountryCd  CountryType           `xml:"LegalDomicileForeignCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	DirectControllingEntityName    *BusinessNameType     `xml:"DirectControllingEntityName,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
+	DirectControllingNACd          DirectControllingNACd `xml:"DirectControllingNACd,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
 	PredominantIncomeTypeTxt       string                `xml:"PredominantIncomeTypeTxt,omitempty" json:",omitempty"`
 	ShareOfTotalIncomeAmt          int                   `xml:"ShareOfTotalIncomeAmt,omitempty" json:",omitempty"`
 	ShareOfEOYAssetsAmt            int                   `xml:"ShareOfEOYAssetsAmt,omitempty" json:",omitempty"`
@@ -1378,16 +1378,16 @@ func (r IdRelatedOrgTxblPartnershipGrp) Validate() error {
 
 type IdRelatedTaxExemptOrgGrp struct {
 	DisregardedEntityName         *BusinessNameType     `xml:"DisregardedEntityName,omitempty" json:",omitempty"`
-	USAddress                     USAddressType         `xml:"USAddress"`
-	ForeignAddress                ForeignAddressType    `xml:"ForeignAddress"`
+	USAddress                     *USAddressType        `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                *ForeignAddressType   `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	EIN                           EINType               `xml:"EIN,omitempty" json:",omitempty"`
 	PrimaryActivitiesTxt          string                `xml:"PrimaryActivitiesTxt,omitempty" json:",omitempty"`
-	LegalDomicileStateCd          StateType             `xml:"LegalDomicileStateCd"`
-	LegalDomicileForeignCountryCd CountryType           `xml:"LegalDomicileForeignCountryCd"`
+	LegalDomicileStateCd          StateType             `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileForeignCountryCd CountryType           `xml:"LegalDomicileForeignCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
 	ExemptCodeSectionTxt          string                `xml:"ExemptCodeSectionTxt,omitempty" json:",omitempty"`
 	PublicCharityStatusTxt        string                `xml:"PublicCharityStatusTxt,omitempty" json:",omitempty"`
-	DirectControllingEntityName   BusinessNameType      `xml:"DirectControllingEntityName"`
-	DirectControllingNACd         DirectControllingNACd `xml:"DirectControllingNACd"`
+	DirectControllingEntityName   *BusinessNameType     `xml:"DirectControllingEntityName,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
+	DirectControllingNACd         DirectControllingNACd `xml:"DirectControllingNACd,omitempty" json:",omitempty" choice:"DirectControllingEntityName"`
 	ControlledOrganizationInd     bool                  `xml:"ControlledOrganizationInd,omitempty" json:",omitempty"`
 }
 
@@ -1436,17 +1436,17 @@ func (r LoanOutstandingInd) Validate() error {
 }
 
 type LoansBtwnOrgInterestedPrsnGrpType struct {
-	PersonNm                    PersonNameType   `xml:"PersonNm"`
-	BusinessName                BusinessNameType `xml:"BusinessName"`
-	RelationshipWithOrgTxt      string           `xml:"RelationshipWithOrgTxt,omitempty" json:",omitempty"`
-	LoanPurposeTxt              string           `xml:"LoanPurposeTxt,omitempty" json:",omitempty"`
-	LoanToOrganizationInd       CheckboxType     `xml:"LoanToOrganizationInd"`
-	LoanFromOrganizationInd     CheckboxType     `xml:"LoanFromOrganizationInd"`
-	OriginalPrincipalAmt        int              `xml:"OriginalPrincipalAmt,omitempty" json:",omitempty"`
-	BalanceDueAmt               int              `xml:"BalanceDueAmt,omitempty" json:",omitempty"`
-	DefaultInd                  bool             `xml:"DefaultInd,omitempty" json:",omitempty"`
-	BoardOrCommitteeApprovalInd bool             `xml:"BoardOrCommitteeApprovalInd,omitempty" json:",omitempty"`
-	WrittenAgreementInd         bool             `xml:"WrittenAgreementInd,omitempty" json:",omitempty"`
+	PersonNm                    PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName                *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	RelationshipWithOrgTxt      string            `xml:"RelationshipWithOrgTxt,omitempty" json:",omitempty"`
+	LoanPurposeTxt              string            `xml:"LoanPurposeTxt,omitempty" json:",omitempty"`
+	LoanToOrganizationInd       CheckboxType      `xml:"LoanToOrganizationInd,omitempty" json:",omitempty" choice:"LoanToOrganizationInd"`
+	LoanFromOrganizationInd     CheckboxType      `xml:"LoanFromOrganizationInd,omitempty" json:",omitempty" choice:"LoanToOrganizationInd"`
+	OriginalPrincipalAmt        int               `xml:"OriginalPrincipalAmt,omitempty" json:",omitempty"`
+	BalanceDueAmt               int               `xml:"BalanceDueAmt,omitempty" json:",omitempty"`
+	DefaultInd                  bool              `xml:"DefaultInd,omitempty" json:",omitempty"`
+	BoardOrCommitteeApprovalInd bool              `xml:"BoardOrCommitteeApprovalInd,omitempty" json:",omitempty"`
+	WrittenAgreementInd         bool              `xml:"WrittenAgreementInd,omitempty" json:",omitempty"`
 }
 
 func (r LoansBtwnOrgInterestedPrsnGrpType) Validate() error {
@@ -1525,10 +1525,10 @@ func (r MoreThan5000KToOrgInd) Validate() error {
 
 // Recurring Name and Address Type
 type NameAndAddressType struct {
-	PersonNm       PersonNameType     `xml:"PersonNm"`
-	BusinessName   BusinessNameType   `xml:"BusinessName"`
-	USAddress      USAddressType      `xml:"USAddress"`
-	ForeignAddress ForeignAddressType `xml:"ForeignAddress"`
+	PersonNm       PersonNameType      `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName   *BusinessNameType   `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	USAddress      *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 }
 
 func (r NameAndAddressType) Validate() error {
@@ -1536,8 +1536,8 @@ func (r NameAndAddressType) Validate() error {
 }
 
 type NameOfInterested struct {
-	PersonNm     PersonNameType   `xml:"PersonNm"`
-	BusinessName BusinessNameType `xml:"BusinessName"`
+	PersonNm     PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
 }
 
 func (r NameOfInterested) Validate() error {
@@ -1545,10 +1545,10 @@ func (r NameOfInterested) Validate() error {
 }
 
 type NonCashPropertyContributionGrpType struct {
-	ContributorNum      int      `xml:"ContributorNum"`
-	NoncashPropertyDesc string   `xml:"NoncashPropertyDesc"`
-	FairMarketValueAmt  int      `xml:"FairMarketValueAmt"`
-	ReceivedDt          DateType `xml:"ReceivedDt,omitempty" json:",omitempty"`
+	ContributorNum      int       `xml:"ContributorNum"`
+	NoncashPropertyDesc string    `xml:"NoncashPropertyDesc"`
+	FairMarketValueAmt  int       `xml:"FairMarketValueAmt"`
+	ReceivedDt          *DateType `xml:"ReceivedDt,omitempty" json:",omitempty"`
 }
 
 func (r NonCashPropertyContributionGrpType) Validate() error {
@@ -1686,10 +1686,10 @@ func (r PractitionerPINGrp) Validate() error {
 }
 
 type PreparerFirmGrp struct {
-	PreparerFirmEIN        EINType            `xml:"PreparerFirmEIN,omitempty" json:",omitempty"`
-	PreparerFirmName       BusinessNameType   `xml:"PreparerFirmName"`
-	PreparerUSAddress      USAddressType      `xml:"PreparerUSAddress"`
-	PreparerForeignAddress ForeignAddressType `xml:"PreparerForeignAddress"`
+	PreparerFirmEIN        EINType             `xml:"PreparerFirmEIN,omitempty" json:",omitempty"`
+	PreparerFirmName       BusinessNameType    `xml:"PreparerFirmName"`
+	PreparerUSAddress      *USAddressType      `xml:"PreparerUSAddress,omitempty" json:",omitempty" choice:"PreparerUSAddress"`
+	PreparerForeignAddress *ForeignAddressType `xml:"PreparerForeignAddress,omitempty" json:",omitempty" choice:"PreparerUSAddress"`
 }
 
 func (r PreparerFirmGrp) Validate() error {
@@ -1698,11 +1698,11 @@ func (r PreparerFirmGrp) Validate() error {
 
 type PreparerPersonGrp struct {
 	PreparerPersonNm PersonNameType  `xml:"PreparerPersonNm,omitempty" json:",omitempty"`
-	SSN              SSNType         `xml:"SSN"`
-	PTIN             PTINType        `xml:"PTIN"`
+	SSN              SSNType         `xml:"SSN,omitempty" json:",omitempty" choice:"SSN"`
+	PTIN             PTINType        `xml:"PTIN,omitempty" json:",omitempty" choice:"SSN"`
 	PhoneNum         PhoneNumberType `xml:"PhoneNum,omitempty" json:",omitempty"`
 	EmailAddressTxt  string          `xml:"EmailAddressTxt,omitempty" json:",omitempty"`
-	PreparationDt    DateType        `xml:"PreparationDt,omitempty" json:",omitempty"`
+	PreparationDt    *DateType       `xml:"PreparationDt,omitempty" json:",omitempty"`
 	SelfEmployedInd  CheckboxType    `xml:"SelfEmployedInd,omitempty" json:",omitempty"`
 }
 
@@ -1742,16 +1742,16 @@ func (r ProgSrvcAccomplishmentActyGrpType) Validate() error {
 }
 
 type RecipientTable struct {
-	RecipientBusinessName   *BusinessNameType  `xml:"RecipientBusinessName,omitempty" json:",omitempty"`
-	USAddress               USAddressType      `xml:"USAddress"`
-	ForeignAddress          ForeignAddressType `xml:"ForeignAddress"`
-	RecipientEIN            EINType            `xml:"RecipientEIN,omitempty" json:",omitempty"`
-	IRCSectionDesc          string             `xml:"IRCSectionDesc,omitempty" json:",omitempty"`
-	CashGrantAmt            int                `xml:"CashGrantAmt,omitempty" json:",omitempty"`
-	NonCashAssistanceAmt    int                `xml:"NonCashAssistanceAmt,omitempty" json:",omitempty"`
-	ValuationMethodUsedDesc string             `xml:"ValuationMethodUsedDesc,omitempty" json:",omitempty"`
-	NonCashAssistanceDesc   string             `xml:"NonCashAssistanceDesc,omitempty" json:",omitempty"`
-	PurposeOfGrantTxt       string             `xml:"PurposeOfGrantTxt,omitempty" json:",omitempty"`
+	RecipientBusinessName   *BusinessNameType   `xml:"RecipientBusinessName,omitempty" json:",omitempty"`
+	USAddress               *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress          *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	RecipientEIN            EINType             `xml:"RecipientEIN,omitempty" json:",omitempty"`
+	IRCSectionDesc          string              `xml:"IRCSectionDesc,omitempty" json:",omitempty"`
+	CashGrantAmt            int                 `xml:"CashGrantAmt,omitempty" json:",omitempty"`
+	NonCashAssistanceAmt    int                 `xml:"NonCashAssistanceAmt,omitempty" json:",omitempty"`
+	ValuationMethodUsedDesc string              `xml:"ValuationMethodUsedDesc,omitempty" json:",omitempty"`
+	NonCashAssistanceDesc   string              `xml:"NonCashAssistanceDesc,omitempty" json:",omitempty"`
+	PurposeOfGrantTxt       string              `xml:"PurposeOfGrantTxt,omitempty" json:",omitempty"`
 }
 
 func (r RecipientTable) Validate() error {
@@ -1819,23 +1819,23 @@ func (r ReportProgramRelatedInvstInd) Validate() error {
 }
 
 type RltdOrgOfficerTrstKeyEmplGrp struct {
-	PersonNm                       PersonNameType   `xml:"PersonNm"`
-	BusinessName                   BusinessNameType `xml:"BusinessName"`
-	TitleTxt                       string           `xml:"TitleTxt,omitempty" json:",omitempty"`
-	BaseCompensationFilingOrgAmt   int              `xml:"BaseCompensationFilingOrgAmt,omitempty" json:",omitempty"`
-	CompensationBasedOnRltdOrgsAmt int              `xml:"CompensationBasedOnRltdOrgsAmt,omitempty" json:",omitempty"`
-	BonusFilingOrganizationAmount  int              `xml:"BonusFilingOrganizationAmount,omitempty" json:",omitempty"`
-	BonusRelatedOrganizationsAmt   int              `xml:"BonusRelatedOrganizationsAmt,omitempty" json:",omitempty"`
-	OtherCompensationFilingOrgAmt  int              `xml:"OtherCompensationFilingOrgAmt,omitempty" json:",omitempty"`
-	OtherCompensationRltdOrgsAmt   int              `xml:"OtherCompensationRltdOrgsAmt,omitempty" json:",omitempty"`
-	DeferredCompensationFlngOrgAmt int              `xml:"DeferredCompensationFlngOrgAmt,omitempty" json:",omitempty"`
-	DeferredCompRltdOrgsAmt        int              `xml:"DeferredCompRltdOrgsAmt,omitempty" json:",omitempty"`
-	NontaxableBenefitsFilingOrgAmt int              `xml:"NontaxableBenefitsFilingOrgAmt,omitempty" json:",omitempty"`
-	NontaxableBenefitsRltdOrgsAmt  int              `xml:"NontaxableBenefitsRltdOrgsAmt,omitempty" json:",omitempty"`
-	TotalCompensationFilingOrgAmt  int              `xml:"TotalCompensationFilingOrgAmt,omitempty" json:",omitempty"`
-	TotalCompensationRltdOrgsAmt   int              `xml:"TotalCompensationRltdOrgsAmt,omitempty" json:",omitempty"`
-	CompReportPrior990FilingOrgAmt int              `xml:"CompReportPrior990FilingOrgAmt,omitempty" json:",omitempty"`
-	CompReportPrior990RltdOrgsAmt  int              `xml:"CompReportPrior990RltdOrgsAmt,omitempty" json:",omitempty"`
+	PersonNm                       PersonNameType    `xml:"PersonNm,omitempty" json:",omitempty" choice:"PersonNm"`
+	BusinessName                   *BusinessNameType `xml:"BusinessName,omitempty" json:",omitempty" choice:"PersonNm"`
+	TitleTxt                       string            `xml:"TitleTxt,omitempty" json:",omitempty"`
+	BaseCompensationFilingOrgAmt   int               `xml:"BaseCompensationFilingOrgAmt,omitempty" json:",omitempty"`
+	CompensationBasedOnRltdOrgsAmt int               `xml:"CompensationBasedOnRltdOrgsAmt,omitempty" json:",omitempty"`
+	BonusFilingOrganizationAmount  int               `xml:"BonusFilingOrganizationAmount,omitempty" json:",omitempty"`
+	BonusRelatedOrganizationsAmt   int               `xml:"BonusRelatedOrganizationsAmt,omitempty" json:",omitempty"`
+	OtherCompensationFilingOrgAmt  int               `xml:"OtherCompensationFilingOrgAmt,omitempty" json:",omitempty"`
+	OtherCompensationRltdOrgsAmt   int               `xml:"OtherCompensationRltdOrgsAmt,omitempty" json:",omitempty"`
+	DeferredCompensationFlngOrgAmt int               `xml:"DeferredCompensationFlngOrgAmt,omitempty" json:",omitempty"`
+	DeferredCompRltdOrgsAmt        int               `xml:"DeferredCompRltdOrgsAmt,omitempty" json:",omitempty"`
+	NontaxableBenefitsFilingOrgAmt int               `xml:"NontaxableBenefitsFilingOrgAmt,omitempty" json:",omitempty"`
+	NontaxableBenefitsRltdOrgsAmt  int               `xml:"NontaxableBenefitsRltdOrgsAmt,omitempty" json:",omitempty"`
+	TotalCompensationFilingOrgAmt  int               `xml:"TotalCompensationFilingOrgAmt,omitempty" json:",omitempty"`
+	TotalCompensationRltdOrgsAmt   int               `xml:"TotalCompensationRltdOrgsAmt,omitempty" json:",omitempty"`
+	CompReportPrior990FilingOrgAmt int               `xml:"CompReportPrior990FilingOrgAmt,omitempty" json:",omitempty"`
+	CompReportPrior990RltdOrgsAmt  int               `xml:"CompReportPrior990RltdOrgsAmt,omitempty" json:",omitempty"`
 }
 
 func (r RltdOrgOfficerTrstKeyEmplGrp) Validate() error {
@@ -1873,12 +1873,12 @@ func (r SchoolOperatingInd) Validate() error {
 }
 
 type Section527PoliticalOrgGrp struct {
-	OrganizationBusinessName *BusinessNameType  `xml:"OrganizationBusinessName,omitempty" json:",omitempty"`
-	USAddress                USAddressType      `xml:"USAddress"`
-	ForeignAddress           ForeignAddressType `xml:"ForeignAddress"`
-	EIN                      EINType            `xml:"EIN,omitempty" json:",omitempty"`
-	PaidInternalFundsAmt     int                `xml:"PaidInternalFundsAmt,omitempty" json:",omitempty"`
-	ContributionsRcvdDlvrAmt int                `xml:"ContributionsRcvdDlvrAmt,omitempty" json:",omitempty"`
+	OrganizationBusinessName *BusinessNameType   `xml:"OrganizationBusinessName,omitempty" json:",omitempty"`
+	USAddress                *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress           *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	EIN                      EINType             `xml:"EIN,omitempty" json:",omitempty"`
+	PaidInternalFundsAmt     int                 `xml:"PaidInternalFundsAmt,omitempty" json:",omitempty"`
+	ContributionsRcvdDlvrAmt int                 `xml:"ContributionsRcvdDlvrAmt,omitempty" json:",omitempty"`
 }
 
 func (r Section527PoliticalOrgGrp) Validate() error {
@@ -1967,21 +1967,21 @@ func (r USItemizedEntryType) Validate() error {
 }
 
 type UnrelatedOrgTxblPartnershipGrp struct {
-	BusinessName                   *BusinessNameType  `xml:"BusinessName,omitempty" json:",omitempty"`
-	USAddress                      USAddressType      `xml:"USAddress"`
-	ForeignAddress                 ForeignAddressType `xml:"ForeignAddress"`
-	EIN                            EINType            `xml:"EIN,omitempty" json:",omitempty"`
-	PrimaryActivitiesTxt           string             `xml:"PrimaryActivitiesTxt,omitempty" json:",omitempty"`
-	LegalDomicileStateCd           StateType          `xml:"LegalDomicileStateCd"`
-	LegalDomicileForeignCountryCd  CountryType        `xml:"LegalDomicileForeignCountryCd"`
-	PredominateIncomeDesc          string             `xml:"PredominateIncomeDesc,omitempty" json:",omitempty"`
-	AllPartnersC3SInd              bool               `xml:"AllPartnersC3SInd,omitempty" json:",omitempty"`
-	ShareOfTotalIncomeAmt          int                `xml:"ShareOfTotalIncomeAmt,omitempty" json:",omitempty"`
-	ShareOfEOYAssetsAmt            int                `xml:"ShareOfEOYAssetsAmt,omitempty" json:",omitempty"`
-	DisproportionateAllocationsInd bool               `xml:"DisproportionateAllocationsInd,omitempty" json:",omitempty"`
-	UBICodeVAmt                    int                `xml:"UBICodeVAmt,omitempty" json:",omitempty"`
-	GeneralOrManagingPartnerInd    bool               `xml:"GeneralOrManagingPartnerInd,omitempty" json:",omitempty"`
-	OwnershipPct                   float64            `xml:"OwnershipPct,omitempty" json:",omitempty"`
+	BusinessName                   *BusinessNameType   `xml:"BusinessName,omitempty" json:",omitempty"`
+	USAddress                      *USAddressType      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                 *ForeignAddressType `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	EIN                            EINType             `xml:"EIN,omitempty" json:",omitempty"`
+	PrimaryActivitiesTxt           string              `xml:"PrimaryActivitiesTxt,omitempty" json:",omitempty"`
+	LegalDomicileStateCd           StateType           `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileForeignCountryCd  CountryType         `xml:"LegalDomicileForeignCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	PredominateIncomeDesc          string              `xml:"PredominateIncomeDesc,omitempty" json:",omitempty"`
+	AllPartnersC3SInd              bool                `xml:"AllPartnersC3SInd,omitempty" json:",omitempty"`
+	ShareOfTotalIncomeAmt          int                 `xml:"ShareOfTotalIncomeAmt,omitempty" json:",omitempty"`
+	ShareOfEOYAssetsAmt            int                 `xml:"ShareOfEOYAssetsAmt,omitempty" json:",omitempty"`
+	DisproportionateAllocationsInd bool                `xml:"DisproportionateAllocationsInd,omitempty" json:",omitempty"`
+	UBICodeVAmt                    int                 `xml:"UBICodeVAmt,omitempty" json:",omitempty"`
+	GeneralOrManagingPartnerInd    bool                `xml:"GeneralOrManagingPartnerInd,omitempty" json:",omitempty"`
+	OwnershipPct                   float64             `xml:"OwnershipPct,omitempty" json:",omitempty"`
 }
 
 func (r UnrelatedOrgTxblPartnershipGrp) Validate() error {
@@ -2000,7 +2000,7 @@ func (r VehicleDescriptionGrpType) Validate() error {
 
 type SupplementalInformationDetail struct {
 	FormAndLineReferenceDesc string `xml:"FormAndLineReferenceDesc,omitempty" json:",omitempty"`
-	ExplanationTxt           string `xml:"ExplanationTxt,omitempty" json:",omitempty"`
+	ExplanationTxt           string `xml:"ExplanationTxt"`
 }
 
 func (r SupplementalInformationDetail) Validate() error {
@@ -2068,7 +2068,7 @@ type TaxExemptBondsIssuesGrpType struct {
 	IssuerName          *BusinessNameType `xml:"IssuerName,omitempty" json:",omitempty"`
 	BondIssuerEIN       EINType           `xml:"BondIssuerEIN,omitempty" json:",omitempty"`
 	CUSIPNum            CUSIPNumberType   `xml:"CUSIPNum,omitempty" json:",omitempty"`
-	BondIssuedDt        DateType          `xml:"BondIssuedDt,omitempty" json:",omitempty"`
+	BondIssuedDt        *DateType         `xml:"BondIssuedDt,omitempty" json:",omitempty"`
 	IssuePriceAmt       int               `xml:"IssuePriceAmt,omitempty" json:",omitempty"`
 	PurposeDesc         string            `xml:"PurposeDesc,omitempty" json:",omitempty"`
 	DefeasedInd         bool              `xml:"DefeasedInd,omitempty" json:",omitempty"`
@@ -2116,7 +2116,7 @@ type TaxExemptBondsProceedsGrpType struct {
 	CapitalExpendituresAmt        int             `xml:"CapitalExpendituresAmt,omitempty" json:",omitempty"`
 	OtherSpentProceedsAmt         int             `xml:"OtherSpentProceedsAmt,omitempty" json:",omitempty"`
 	UnspentAmt                    int             `xml:"UnspentAmt,omitempty" json:",omitempty"`
-	SubstantialCompletionYr       YearType        `xml:"SubstantialCompletionYr,omitempty" json:",omitempty"`
+	SubstantialCompletionYr       *YearType       `xml:"SubstantialCompletionYr,omitempty" json:",omitempty"`
 	CurrentRefundingInd           bool            `xml:"CurrentRefundingInd,omitempty" json:",omitempty"`
 	AdvanceRefundingInd           bool            `xml:"AdvanceRefundingInd,omitempty" json:",omitempty"`
 	FinalAllocationMadeInd        bool            `xml:"FinalAllocationMadeInd,omitempty" json:",omitempty"`
@@ -2234,10 +2234,10 @@ type Acknowledgement struct {
 	AcceptanceStatusTxt         string                        `xml:"AcceptanceStatusTxt"`
 	ContainedAlertsInd          bool                          `xml:"ContainedAlertsInd"`
 	StatusDt                    DateType                      `xml:"StatusDt"`
-	TIN                         EINType                       `xml:"TIN"`
-	TempId                      TempIdType                    `xml:"TempId"`
-	ExpectedRefundAmt           int                           `xml:"ExpectedRefundAmt"`
-	BalanceDueAmt               int                           `xml:"BalanceDueAmt"`
+	TIN                         EINType                       `xml:"TIN,omitempty" json:",omitempty" choice:"TIN"`
+	TempId                      TempIdType                    `xml:"TempId,omitempty" json:",omitempty" choice:"TIN"`
+	ExpectedRefundAmt           int                           `xml:"ExpectedRefundAmt,omitempty" json:",omitempty" choice:"ExpectedRefundAmt,optional"`
+	BalanceDueAmt               int                           `xml:"BalanceDueAmt,omitempty" json:",omitempty" choice:"ExpectedRefundAmt,optional"`
 	TaxYr                       *YearType                     `xml:"TaxYr,omitempty" json:",omitempty"`
 	ElectronicPostmarkTs        *TimestampType                `xml:"ElectronicPostmarkTs,omitempty" json:",omitempty"`
 	IRSSubmissionId             *SubmissionIdType             `xml:"IRSSubmissionId,omitempty" json:",omitempty"`
@@ -0,0 +1,102 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+)
+
+var (
+	// ErrMismatchedDocumentCnt is given when documentCnt doesn't match the documents of return data
+	ErrMismatchedDocumentCnt = errors.New("documentCnt doesn't match the documents of return data")
+	// ErrMismatchedBinaryAttachmentCnt is given when binaryAttachmentCnt doesn't match the binary attachments
+	ErrMismatchedBinaryAttachmentCnt = errors.New("binaryAttachmentCnt doesn't match the binary attachments")
+	// ErrDuplicatedDocumentId is given when a documentId is used by more than one document
+	ErrDuplicatedDocumentId = errors.New("documentId is duplicated")
+	// ErrUnresolvedAttachmentLocation is given when an attachment location isn't a file of the submission zip
+	ErrUnresolvedAttachmentLocation = errors.New("attachment location isn't a file of the submission zip")
+)
+
+var documentIdField = "DocumentId"
+
+// Documents returns the documentId of every document in return data, in xml order
+func (r *ReturnData) Documents() []IdType {
+	var documents []IdType
+
+	fields := reflect.ValueOf(r).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		fieldData := fields.Field(i)
+		switch fieldData.Kind() {
+		case reflect.Ptr:
+			if !fieldData.IsNil() {
+				documents = append(documents, documentId(fieldData.Elem()))
+			}
+		case reflect.Slice:
+			for j := 0; j < fieldData.Len(); j++ {
+				documents = append(documents, documentId(fieldData.Index(j)))
+			}
+		}
+	}
+
+	return documents
+}
+
+func documentId(document reflect.Value) IdType {
+	id := document.FieldByName(documentIdField)
+	if !id.IsValid() {
+		return ""
+	}
+	return IdType(id.String())
+}
+
+// ValidateConsistency checks the header counts against the documents of return data
+// and that every documentId is unique
+func (r *Return) ValidateConsistency() error {
+	documents := r.ReturnData.Documents()
+	if r.ReturnData.DocumentCnt != len(documents) {
+		return fmt.Errorf("%w: documentCnt is %d, has %d documents", ErrMismatchedDocumentCnt, r.ReturnData.DocumentCnt, len(documents))
+	}
+
+	attachments := len(r.ReturnData.BinaryAttachment)
+	if r.ReturnHeader.BinaryAttachmentCnt != attachments {
+		return fmt.Errorf("%w: binaryAttachmentCnt is %d, has %d binary attachments", ErrMismatchedBinaryAttachmentCnt, r.ReturnHeader.BinaryAttachmentCnt, attachments)
+	}
+
+	ids := make(map[IdType]bool, len(documents))
+	for _, id := range documents {
+		if ids[id] {
+			return fmt.Errorf("%w: %s", ErrDuplicatedDocumentId, id)
+		}
+		ids[id] = true
+	}
+
+	return nil
+}
+
+// ValidateAttachmentLocations checks that every attachment location resolves to one of
+// the file names of the submission zip
+func (r *Return) ValidateAttachmentLocations(zipFiles []string) error {
+	files := make(map[string]bool, len(zipFiles))
+	for _, name := range zipFiles {
+		files[path.Clean(name)] = true
+	}
+
+	for _, attachment := range r.ReturnData.BinaryAttachment {
+		if !files[path.Clean(attachment.AttachmentLocationTxt)] {
+			return fmt.Errorf("%w: %s", ErrUnresolvedAttachmentLocation, attachment.AttachmentLocationTxt)
+		}
+	}
+
+	return nil
+}
+
+// FixConsistency sets documentCnt and binaryAttachmentCnt from the contents of return data
+func (r *Return) FixConsistency() {
+	r.ReturnData.DocumentCnt = len(r.ReturnData.Documents())
+	r.ReturnHeader.BinaryAttachmentCnt = len(r.ReturnData.BinaryAttachment)
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrDuplicateSubmissionId is given when the submission id was used by a previous submission
+	ErrDuplicateSubmissionId = errors.New("submission id was already used")
+	// ErrDuplicateOriginal is given when an original return was already submitted for the EIN and tax period
+	ErrDuplicateOriginal = errors.New("original return was already submitted for the tax period")
+	// ErrMissingOriginal is given when an amended return has no original return for the EIN and tax period
+	ErrMissingOriginal = errors.New("amended return has no original return for the tax period")
+)
+
+// SubmissionRecord describes a previously built submission
+type SubmissionRecord struct {
+	SubmissionId     SubmissionIdType
+	EIN              EINType
+	ReturnTypeCd     ReturnTypeCd
+	TaxPeriodBeginDt DateType
+	TaxPeriodEndDt   DateType
+	Amended          bool
+	// Rejected submissions don't count as filed returns
+	Rejected bool
+}
+
+// NewSubmissionRecord returns the record of the submission of a file
+func NewSubmissionRecord(file *Irs990File) SubmissionRecord {
+	header := file.XmlData.ReturnHeader
+	record := SubmissionRecord{
+		EIN:              header.Filer.EIN,
+		ReturnTypeCd:     header.ReturnTypeCd,
+		TaxPeriodBeginDt: header.TaxPeriodBeginDt,
+		TaxPeriodEndDt:   header.TaxPeriodEndDt,
+		Amended:          file.XmlData.IsAmended(),
+	}
+	if file.Manifest != nil {
+		record.SubmissionId = file.Manifest.SubmissionId
+	}
+	return record
+}
+
+// IsAmended reports whether the return is an amended return
+func (r *Return) IsAmended() bool {
+	return r.ReturnData.IRS990 != nil && len(r.ReturnData.IRS990.AmendedReturnInd) > 0
+}
+
+// SubmissionStore holds the previously built submissions
+type SubmissionStore interface {
+	// FindSubmission returns the submission of the submission id, nil when there isn't one
+	FindSubmission(id SubmissionIdType) (*SubmissionRecord, error)
+	// FindReturns returns the submissions of the EIN, return type and tax period ending date
+	FindReturns(ein EINType, returnType ReturnTypeCd, end DateType) ([]SubmissionRecord, error)
+}
+
+// CheckDuplicates checks a new submission against the previous submissions of the store.
+// The submission id must be new, an original return must be the first accepted original
+// of the EIN and tax period, and an amended return must have an original.
+func CheckDuplicates(file *Irs990File, store SubmissionStore) error {
+	record := NewSubmissionRecord(file)
+
+	if len(record.SubmissionId) > 0 {
+		previous, err := store.FindSubmission(record.SubmissionId)
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			return fmt.Errorf("%w: %s", ErrDuplicateSubmissionId, record.SubmissionId)
+		}
+	}
+
+	returns, err := store.FindReturns(record.EIN, record.ReturnTypeCd, record.TaxPeriodEndDt)
+	if err != nil {
+		return err
+	}
+	hasOriginal := false
+	for _, previous := range returns {
+		if !previous.Rejected && !previous.Amended {
+			hasOriginal = true
+			break
+		}
+	}
+
+	if !record.Amended && hasOriginal {
+		return fmt.Errorf("%w: EIN %s, tax period ending %s", ErrDuplicateOriginal, record.EIN, dateString(record.TaxPeriodEndDt))
+	}
+	if record.Amended && !hasOriginal {
+		return fmt.Errorf("%w: EIN %s, tax period ending %s", ErrMissingOriginal, record.EIN, dateString(record.TaxPeriodEndDt))
+	}
+	return nil
+}
+
+// MemorySubmissionStore is a submission store in memory
+type MemorySubmissionStore struct {
+	mu      sync.RWMutex
+	records []SubmissionRecord
+}
+
+// NewMemorySubmissionStore returns an empty submission store
+func NewMemorySubmissionStore() *MemorySubmissionStore {
+	return &MemorySubmissionStore{}
+}
+
+// Add adds a submission to the store
+func (s *MemorySubmissionStore) Add(record SubmissionRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+}
+
+// FindSubmission returns the submission of the submission id, nil when there isn't one
+func (s *MemorySubmissionStore) FindSubmission(id SubmissionIdType) (*SubmissionRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, record := range s.records {
+		if record.SubmissionId == id {
+			found := record
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindReturns returns the submissions of the EIN, return type and tax period ending date
+func (s *MemorySubmissionStore) FindReturns(ein EINType, returnType ReturnTypeCd, end DateType) ([]SubmissionRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var records []SubmissionRecord
+	for _, record := range s.records {
+		if record.EIN == ein && record.ReturnTypeCd == returnType && sameDate(record.TaxPeriodEndDt, end) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
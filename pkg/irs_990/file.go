@@ -14,19 +14,123 @@ import (
 	"github.com/moov-io/1120x/pkg/utils"
 )
 
+var (
+	xmlZipFile      = filepath.Join("xml", "submission.xml")
+	manifestZipFile = filepath.Join("manifest", "manifest.xml")
+)
+
 type Irs990File struct {
 	XmlData  Return                 `xml:"ReturnXml"`
 	Manifest *IRSSubmissionManifest `xml:"Manifest,omitempty" json:",omitempty"`
+
+	// Attachments are the binary attachment files, written to the attachment directory of the submission zip
+	Attachments []Attachment `xml:"-" json:",omitempty"`
+
+	// TestCd is the test indicator of the transmission of the submission, the TestCd of the
+	// MeF header: T for a test (ATS) submission and P for production. It isn't part of the
+	// submission zip, a file without test indicator is a production submission.
+	TestCd string `xml:"-" json:",omitempty"`
+
+	// BlockingSeverities are the severities of validation issues that stop ZipData
+	// besides the rejects, which always stop it
+	BlockingSeverities []utils.Severity `xml:"-" json:",omitempty"`
 }
 
 func (r Irs990File) Validate() error {
-	return utils.Validate(&r)
+	if err := r.validateReturn(); err != nil {
+		return err
+	}
+	return r.validateManifest()
+}
+
+// IsTest reports whether the file is a test submission, only test submissions may use test identifiers
+func (r Irs990File) IsTest() bool {
+	return r.TestCd == utils.TestCd
+}
+
+func (r Irs990File) validateReturn() error {
+	if err := r.XmlData.validate(r.IsTest()); err != nil {
+		return err
+	}
+	if r.Manifest != nil {
+		if err := r.Manifest.Validate(); err != nil {
+			return err
+		}
+		if !r.IsTest() {
+			if err := utils.ValidateProductionIdentifiers(r.Manifest); err != nil {
+				return err
+			}
+		}
+	}
+	if err := r.validateAttachments(); err != nil {
+		return err
+	}
+	return r.XmlData.ValidateAttachmentLocations(r.ZipFiles())
+}
+
+func (r Irs990File) validateManifest() error {
+	if r.Manifest == nil {
+		return nil
+	}
+	return r.Manifest.ValidateReturn(r.XmlData.ReturnHeader)
+}
+
+// ValidationResult validates the file and returns every issue with its severity.
+// Like MeF, which stops processing a submission that fails schema validation, a manifest
+// that doesn't match the return and the invalid elements of the return or the manifest are
+// Reject and Stop and leave out the business rules. The broken business rules are rejects
+// and the warnings of the return are alerts.
+func (r Irs990File) ValidationResult() utils.ValidationResult {
+	var result utils.ValidationResult
+	result.Add(utils.SeverityRejectAndStop, r.validateManifest())
+	for _, err := range utils.ValidateAll(&r.XmlData) {
+		result.Add(utils.SeverityRejectAndStop, err)
+	}
+	if r.Manifest != nil {
+		for _, err := range utils.ValidateAll(r.Manifest) {
+			result.Add(utils.SeverityRejectAndStop, err)
+		}
+	}
+	result.Add(utils.SeverityRejectAndStop, r.XmlData.ValidateVersion())
+	if len(result.Issues) > 0 {
+		return result
+	}
+
+	for _, err := range r.XmlData.RuleErrors() {
+		result.Add(utils.SeverityReject, err)
+	}
+	if r.Manifest != nil {
+		result.Add(utils.SeverityReject, utils.ValidateHooks(r.Manifest, "IRSSubmissionManifest"))
+	}
+	if !r.IsTest() {
+		result.Add(utils.SeverityReject, utils.ValidateProductionIdentifiers(&r))
+	}
+	for _, err := range r.attachmentErrors() {
+		result.Add(utils.SeverityReject, err)
+	}
+	result.Add(utils.SeverityReject, r.XmlData.ValidateAttachmentLocations(r.ZipFiles()))
+	for _, warning := range r.XmlData.Warnings() {
+		result.Add(utils.SeverityAlert, warning)
+	}
+	return result
+}
+
+// ZipFiles returns file names of the submission zip
+func (r Irs990File) ZipFiles() []string {
+	files := []string{xmlZipFile, manifestZipFile}
+	for _, attachment := range r.Attachments {
+		files = append(files, attachment.ZipFile())
+	}
+	return files
 }
 
 func (r *Irs990File) ZipData() ([]byte, error) {
 	if r.Manifest == nil {
 		return nil, errors.New("manifest should not empty")
 	}
+	if err := r.ValidationResult().Err(r.BlockingSeverities...); err != nil {
+		return nil, err
+	}
 
 	// Create a buffer to write our archive to.
 	fileBuf := new(bytes.Buffer)
@@ -43,7 +147,7 @@ func (r *Irs990File) ZipData() ([]byte, error) {
 	// Create a new zip archive.
 	writer := zip.NewWriter(fileBuf)
 
-	f, err := writer.Create(filepath.Join("xml", "submission.xml"))
+	f, err := writer.Create(xmlZipFile)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +156,7 @@ func (r *Irs990File) ZipData() ([]byte, error) {
 		return nil, err
 	}
 
-	f, err = writer.Create(filepath.Join("manifest", "manifest.xml"))
+	f, err = writer.Create(manifestZipFile)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +165,17 @@ func (r *Irs990File) ZipData() ([]byte, error) {
 		return nil, err
 	}
 
+	for _, attachment := range r.Attachments {
+		f, err = writer.Create(attachment.ZipFile())
+		if err != nil {
+			return nil, err
+		}
+		_, err = f.Write(attachment.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = writer.Close()
 	return fileBuf.Bytes(), err
 }
@@ -0,0 +1,56 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Format is the expected format of a simple type, taken from its Validate method
+type Format struct {
+	Pattern   string
+	Values    []string
+	MinLength int
+	MaxLength int
+	// Rules are the checks of the Validate method beyond the pattern and the lengths
+	Rules []string
+}
+
+// Rules of the identifier and bank account validators
+const (
+	RuleEINPrefix          = "EINPrefix"
+	RuleSSNOrITIN          = "SSNOrITIN"
+	RulePTINNotZero        = "PTINNotZero"
+	RuleRoutingCheckDigit  = "RoutingCheckDigit"
+	RuleBankAccountNotZero = "BankAccountNotZero"
+)
+
+// validatorPattern returns the pattern of an anchored validator regular expression
+func validatorPattern(reg *regexp.Regexp) string {
+	return strings.TrimSuffix(strings.TrimPrefix(reg.String(), "^"), "$")
+}
+
+//go:generate go run gen_formats.go
+
+// identifierFormats are the formats of the identifier and bank account types, taken from their validators
+var identifierFormats = map[string]Format{
+	"BankAccountNumberType":    {Pattern: validatorPattern(bankAccountPattern), MaxLength: bankAccountMaxLength, Rules: []string{RuleBankAccountNotZero}},
+	"EFINType":                 {Pattern: validatorPattern(efinPattern)},
+	"EINType":                  {Pattern: validatorPattern(einPattern), Rules: []string{RuleEINPrefix}},
+	"ETINType":                 {Pattern: validatorPattern(etinPattern)},
+	"PTINType":                 {Pattern: validatorPattern(ptinPattern), Rules: []string{RulePTINNotZero}},
+	"RoutingTransitNumberType": {Pattern: validatorPattern(routingNumberPattern), Rules: []string{RuleRoutingCheckDigit}},
+	"SSNType":                  {Pattern: validatorPattern(ssnPattern), Rules: []string{RuleSSNOrITIN}},
+}
+
+// ExpectedFormat returns the expected format of the simple type named typeName
+func ExpectedFormat(typeName string) (Format, bool) {
+	if format, ok := identifierFormats[typeName]; ok {
+		return format, true
+	}
+	format, ok := validatorFormats[typeName]
+	return format, ok
+}
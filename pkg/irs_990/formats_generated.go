@@ -0,0 +1,74 @@
+// Code generated by gen_formats.go from types.go; DO NOT EDIT.
+
+package irs_990
+
+// validatorFormats are the patterns, values and lengths checked by the Validate methods of types.go
+var validatorFormats = map[string]Format{
+	"AllStatesCd":                    {Values: []string{"All States"}},
+	"AlphaNumericAndParenthesesType": {Pattern: `[A-Za-z0-9\(\)]*`},
+	"AlphaNumericType":               {Pattern: `[A-Za-z0-9]*`},
+	"BankAccountType":                {Values: []string{"1", "2"}},
+	"BondReferenceCd":                {Pattern: `[A-D]`},
+	"BusinessCd":                     {Pattern: `[0-9]{6}`},
+	"BusinessNameControlType":        {Pattern: `([A-Z0-9\-]|&){1,4}`},
+	"BusinessNameLine1Type":          {Pattern: `(([A-Za-z0-9#\-\(\)]|&|') ?)*([A-Za-z0-9#\-\(\)]|&|')`},
+	"BusinessNameLine2Type":          {Pattern: `(([A-Za-z0-9#/%\-\(\)]|&|') ?)*([A-Za-z0-9#/%\-\(\)]|&|')`},
+	"CHNAConductedYr":                {Pattern: `[0-9]{2}`},
+	"CUSIPNumberType":                {MaxLength: 9},
+	"CheckDigitType":                 {Pattern: `[A-Z]{2}`},
+	"CityType":                       {Pattern: `([A-Za-z] ?)*[A-Za-z]`},
+	"ComputedCRC32Num":               {Pattern: `0x[0-9A-Fa-f]{1,8}`},
+	"ConsortiumType":                 {Values: []string{"English Free-File", "Spanish Free-File", "Free Fillable Forms", "Free File VITA"}},
+	"DepreciationConventionCodeType": {Values: []string{"HY", "MQ", "MM", "S/L"}},
+	"DepreciationMethodCodeType":     {Values: []string{"200 DB", "150 DB", "DB", "S/L", "Various"}},
+	"DeviceIdType":                   {Pattern: `[A-Fa-f0-9]{40}`},
+	"EmbeddedCRC32Num":               {Pattern: `0x[0-9A-Fa-f]{1,8}`},
+	"FUTAStateCdType":                {Values: []string{"AL", "AK", "AZ", "AR", "CA", "CO", "CT", "DE", "DC", "FL", "GA", "HI", "ID", "IL", "IN", "IA", "KS", "KY", "LA", "ME", "MD", "MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV", "NH", "NJ", "NM", "NY", "NC", "ND", "OH", "OK", "OR", "PA", "PR", "RI", "SC", "SD", "TN", "TX", "VI", "UT", "VT", "VA", "WA", "WV", "WI", "WY"}},
+	"FederalEIN":                     {MaxLength: 9},
+	"FederalSubmissionTypeCd":        {Values: []string{"56", "720", "940", "940PR", "941", "941PR", "941SS", "943", "943PR", "944", "945", "990", "990EZ", "990N", "990PF", "1040", "1040A", "1040EZ", "1040PR", "1040SS", "1041", "1120", "1120F", "1120POL", "1120S", "1065", "1065B", "2290", "2350", "4868", "7004", "8849", "8868", "9465"}},
+	"ForeignEntityReferenceIdNum":    {MaxLength: 1},
+	"ForeignPhoneNumberType":         {Pattern: `[0-9]{1,30}`},
+	"GovernmentCodeType":             {Values: []string{"IRS", "ALST", "AKST", "AZST", "ARST", "CAST", "CNCT", "COCT", "COST", "CTST", "DECT", "DEST", "DCST", "FLST", "GAST", "HIST", "IDST", "ILST", "INST", "IAST", "KACT", "KSST", "KYST", "LAST", "LECT", "LXCT", "MEST", "MDST", "MAST", "MIST", "MNST", "MSST", "MOST", "MTST", "NEST", "NVST", "NHST", "NJST", "NMST", "NYST", "NCST", "NDST", "OCCT", "OHST", "OKST", "ORCT", "ORST", "PAST", "RIST", "SCST", "SDST", "SLCT", "TNST", "TOCT", "TXST", "UTST", "VTST", "VAST", "WAST", "WVST", "WIST", "WYST", "NYCT", "PHCT"}},
+	"GroupExemptionNum":              {Pattern: `\d{4}`},
+	"IPv4Type":                       {Pattern: `[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}`},
+	"IPv6Type":                       {Pattern: `[0-9A-F]{1,4}:[0-9A-F]{1,4}:[0-9A-F]{1,4}:[0-9A-F]{1,4}:[0-9A-F]{1,4}:[0-9A-F]{1,4}:[0-9A-F]{1,4}:[0-9A-F]{1,4}`},
+	"IRSServiceCenterType":           {Pattern: `efile|.*`},
+	"ISPType":                        {Pattern: `[A-Z0-9]{6}`},
+	"IdType":                         {Pattern: `[A-Za-z0-9:\.\-]{1,30}`},
+	"ImplementationStrategyAdptYr":   {Pattern: `[0-9]{2}`},
+	"InCareOfNameType":               {Pattern: `(% )(([A-Za-z0-9#/%\-\(\)]|&|') ?)*([A-Za-z0-9#/%\-\(\)]|&|')`},
+	"MethodOfAccountingType":         {Values: []string{"cash", "accrual", "hybrid"}},
+	"MethodValuationCd":              {Values: []string{"C", "F"}},
+	"NameLine1Type":                  {Pattern: `[A-Za-z]( |<)?(([A-Za-z#\-]|&)( |<)?)*([A-Za-z#\-]|&)`},
+	"NumericType":                    {Pattern: `[0-9]*`},
+	"Organization501cTypeTxt":        {Pattern: `[2-9]|1[0-9]|2[02-7]`},
+	"OrganizationTypeCd":             {Values: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}},
+	"OriginatorType":                 {Values: []string{"ERO", "OnlineFiler", "ReportingAgent", "IRSAgent", "FinancialAgent", "LargeTaxpayer"}},
+	"PINCodeType":                    {Values: []string{"Practitioner", "Self-Select Practitioner", "Self-Select On-Line"}},
+	"PINEnteredByCd":                 {Values: []string{"Taxpayer", "ERO"}},
+	"PINEnteredByType":               {Values: []string{"Taxpayer", "ERO"}},
+	"PINType":                        {Pattern: `[0-9]{5}`},
+	"PersonFirstNameType":            {Pattern: `([A-Za-z\-] ?)*[A-Za-z\-]`},
+	"PersonLastNameType":             {Pattern: `([A-Za-z\-] ?)*[A-Za-z\-]`},
+	"PersonNameControlType":          {Pattern: `[A-Z][A-Z\- ]{0,3}`},
+	"PersonNameType":                 {Pattern: `([A-Za-z0-9'\-] ?)*[A-Za-z0-9'\-]`},
+	"PersonTitleType":                {Pattern: `([!-~] ?)*[!-~]`},
+	"PhoneNumberType":                {Pattern: `[0-9]{10}`},
+	"RegistrationNumType":            {Pattern: `[A-Z0-9]{1,20}`},
+	"ReturnTypeCd":                   {Values: []string{"990", "990EZ", "990PF"}},
+	"STINType":                       {Pattern: `S[0-9]{8}`},
+	"SignatureOptionCd":              {Values: []string{"PIN Number", "Binary Attachment 8453 Signature Document"}},
+	"SignatureType":                  {Pattern: `[0-9]{10}`},
+	"SoftwareIdType":                 {Pattern: `[0-9]{8}`},
+	"StateSubmissionTyp":             {Pattern: `[A-Za-z0-9\-]+`},
+	"StreetAddressType":              {Pattern: `[A-Za-z0-9]( ?[A-Za-z0-9\-/])*`},
+	"SubmissionCategoryType":         {Values: []string{"CORP", "CORPEP", "EMPL", "EO", "ESTRST", "ESTRSTEP", "ETEC", "IND", "INDEP", "PART", "PARTEP"}},
+	"SubmissionIdType":               {Pattern: `[0-9]{13}[a-z0-9]{7}`},
+	"SubmissionTyp":                  {Pattern: `[A-Za-z0-9\-]+`},
+	"TaxShelterRegistrationType":     {Pattern: `(MA[0-9]{7})|([0-9]{11})`},
+	"TaxYearEndMonthDtType":          {Pattern: `[0-9][0-9](01|02|03|04|05|06|07|08|09|10|11|12)`},
+	"TempIdType":                     {MaxLength: 9},
+	"TimezoneType":                   {Values: []string{"US", "ES", "ED", "CS", "CD", "MS", "MD", "PS", "PD", "AS", "AD", "HS", "HD"}},
+	"VINType":                        {Pattern: `[A-HJ-NPR-Z0-9]{1,17}|[A-HJ-NPR-Z0-9]{19}`},
+	"ZIPCodeType":                    {Pattern: `[0-9]{5}(([0-9]{4})|([0-9]{7}))?`},
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// gen_formats writes formats_generated.go, the expected formats of the simple types
+// taken from the Validate methods of types.go:
+//
+//	regexp.MustCompile(`pattern`)      the pattern
+//	for _, vv := range []string{...}   the values
+//	r != "value"                       the value
+//	len(r) > n                         the maximum length
+//
+// Run it with go generate after changing types.go.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type typeFormat struct {
+	pattern   string
+	values    []string
+	maxLength int
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "types.go", nil, 0)
+	if err != nil {
+		fail(err)
+	}
+
+	formats := map[string]*typeFormat{}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "Validate" {
+			continue
+		}
+		recv, ok := fn.Recv.List[0].Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		format := &typeFormat{}
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "MustCompile" {
+					format.pattern = stringLit(node.Args[0])
+				}
+			case *ast.CompositeLit:
+				for _, elt := range node.Elts {
+					if value := stringLit(elt); len(value) > 0 {
+						format.values = append(format.values, strings.TrimSpace(value))
+					}
+				}
+			case *ast.BinaryExpr:
+				if node.Op == token.NEQ && isReceiver(node.X) {
+					if value := stringLit(node.Y); len(value) > 0 {
+						format.values = append(format.values, value)
+					}
+				}
+				if call, ok := node.X.(*ast.CallExpr); ok && node.Op == token.GTR && isLen(call) {
+					if lit, ok := node.Y.(*ast.BasicLit); ok && lit.Kind == token.INT {
+						format.maxLength, _ = strconv.Atoi(lit.Value)
+					}
+				}
+			}
+			return true
+		})
+		if len(format.pattern) > 0 || len(format.values) > 0 || format.maxLength > 0 {
+			formats[recv.Name] = format
+		}
+	}
+
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_formats.go from types.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package irs_990\n\n")
+	buf.WriteString("// validatorFormats are the patterns, values and lengths checked by the Validate methods of types.go\n")
+	buf.WriteString("var validatorFormats = map[string]Format{\n")
+	for _, name := range names {
+		format := formats[name]
+		var fields []string
+		if len(format.pattern) > 0 {
+			fields = append(fields, "Pattern: "+quote(format.pattern))
+		}
+		if len(format.values) > 0 {
+			values := make([]string, len(format.values))
+			for i, value := range format.values {
+				values[i] = strconv.Quote(value)
+			}
+			fields = append(fields, "Values: []string{"+strings.Join(values, ", ")+"}")
+		}
+		if format.maxLength > 0 {
+			fields = append(fields, "MaxLength: "+strconv.Itoa(format.maxLength))
+		}
+		fmt.Fprintf(&buf, "%q: {%s},\n", name, strings.Join(fields, ", "))
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fail(err)
+	}
+	if err = os.WriteFile("formats_generated.go", src, 0o644); err != nil {
+		fail(err)
+	}
+}
+
+func stringLit(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		fail(err)
+	}
+	return value
+}
+
+func isReceiver(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "r"
+}
+
+func isLen(call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == "len" && len(call.Args) == 1 && isReceiver(call.Args[0])
+}
+
+// quote keeps the patterns readable as raw strings when they can be
+func quote(s string) string {
+	if strings.Contains(s, "`") || !strconv.CanBackquote(s) {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gen_formats:", err)
+	os.Exit(1)
+}
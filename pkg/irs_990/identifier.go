@@ -0,0 +1,91 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"regexp"
+	"strings"
+)
+
+// EINs with this prefix are never issued, the Assurance Testing System (ATS) of IRS
+// Publication 4164 uses them for the test returns. There is no test prefix for EFINs and
+// ETINs, the IRS keeps their test or production status with the e-file application.
+var testEINPrefix = "00"
+
+var (
+	einPattern  = regexp.MustCompile(`^[0-9]{9}$`)
+	ssnPattern  = regexp.MustCompile(`^[0-9]{9}$`)
+	ptinPattern = regexp.MustCompile(`^P[0-9]{8}$`)
+	efinPattern = regexp.MustCompile(`^[0-9]{6}$`)
+	etinPattern = regexp.MustCompile(`^[0-9]{5}$`)
+)
+
+// Prefixes of EINs assigned by the IRS campuses and the internet
+var einPrefixes = map[string]bool{
+	"01": true, "02": true, "03": true, "04": true, "05": true, "06": true,
+	"10": true, "11": true, "12": true, "13": true, "14": true, "15": true, "16": true,
+	"20": true, "21": true, "22": true, "23": true, "24": true, "25": true, "26": true, "27": true,
+	"30": true, "31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "37": true, "38": true, "39": true,
+	"40": true, "41": true, "42": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true,
+	"50": true, "51": true, "52": true, "53": true, "54": true, "55": true, "56": true, "57": true, "58": true, "59": true,
+	"60": true, "61": true, "62": true, "63": true, "64": true, "65": true, "66": true, "67": true, "68": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true, "77": true,
+	"80": true, "81": true, "82": true, "83": true, "84": true, "85": true, "86": true, "87": true, "88": true,
+	"90": true, "91": true, "92": true, "93": true, "94": true, "95": true, "98": true, "99": true,
+}
+
+// IsValidEIN reports whether ein has nine digits and a valid campus prefix, test EINs are valid
+func IsValidEIN(ein string) bool {
+	if !einPattern.MatchString(ein) {
+		return false
+	}
+	return einPrefixes[ein[:2]] || IsTestEIN(ein)
+}
+
+// IsTestEIN reports whether ein is an ATS test EIN
+func IsTestEIN(ein string) bool {
+	return einPattern.MatchString(ein) && strings.HasPrefix(ein, testEINPrefix)
+}
+
+// IsValidSSN reports whether ssn is a valid social security number.
+// The area can't be 000, 666 or 9xx, the group can't be 00 and the serial can't be 0000.
+func IsValidSSN(ssn string) bool {
+	if !ssnPattern.MatchString(ssn) {
+		return false
+	}
+	area, group, serial := ssn[:3], ssn[3:5], ssn[5:]
+	if area == "000" || area == "666" || area[0] == '9' {
+		return false
+	}
+	return group != "00" && serial != "0000"
+}
+
+// IsValidITIN reports whether itin is a valid individual taxpayer identification number.
+// An ITIN begins with 9 and its group is in the ranges 50-65, 70-88, 90-92 or 94-99.
+func IsValidITIN(itin string) bool {
+	if !ssnPattern.MatchString(itin) || itin[0] != '9' {
+		return false
+	}
+	group := itin[3:5]
+	return (group >= "50" && group <= "65") ||
+		(group >= "70" && group <= "88") ||
+		(group >= "90" && group <= "92") ||
+		(group >= "94" && group <= "99")
+}
+
+// IsValidPTIN reports whether ptin is P followed by eight digits that aren't all zeros
+func IsValidPTIN(ptin string) bool {
+	return ptinPattern.MatchString(ptin) && ptin[1:] != "00000000"
+}
+
+// IsValidEFIN reports whether efin has six digits
+func IsValidEFIN(efin string) bool {
+	return efinPattern.MatchString(efin)
+}
+
+// IsValidETIN reports whether etin has five digits
+func IsValidETIN(etin string) bool {
+	return etinPattern.MatchString(etin)
+}
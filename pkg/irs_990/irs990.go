@@ -13,18 +13,18 @@ type IRS990 struct {
 	FinalReturnInd                 CheckboxType                        `xml:"FinalReturnInd,omitempty" json:",omitempty"`
 	AmendedReturnInd               CheckboxType                        `xml:"AmendedReturnInd,omitempty" json:",omitempty"`
 	DoingBusinessAsName            *BusinessNameType                   `xml:"DoingBusinessAsName,omitempty" json:",omitempty"`
-	PrincipalOfficerNm             PersonNameType                      `xml:"PrincipalOfficerNm"`
-	PrincipalOfcrBusinessName      BusinessNameType                    `xml:"PrincipalOfcrBusinessName"`
-	USAddress                      USAddressType                       `xml:"USAddress"`
-	ForeignAddress                 ForeignAddressType                  `xml:"ForeignAddress"`
+	PrincipalOfficerNm             PersonNameType                      `xml:"PrincipalOfficerNm,omitempty" json:",omitempty" choice:"PrincipalOfficerNm"`
+	PrincipalOfcrBusinessName      *BusinessNameType                   `xml:"PrincipalOfcrBusinessName,omitempty" json:",omitempty" choice:"PrincipalOfficerNm"`
+	USAddress                      *USAddressType                      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                 *ForeignAddressType                 `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	GrossReceiptsAmt               int                                 `xml:"GrossReceiptsAmt"`
 	GroupReturnForAffiliatesInd    bool                                `xml:"GroupReturnForAffiliatesInd"`
 	AllAffiliatesIncludedInd       *AllAffiliatesIncludedInd           `xml:"AllAffiliatesIncludedInd,omitempty" json:",omitempty"`
 	GroupExemptionNum              *GroupExemptionNum                  `xml:"GroupExemptionNum,omitempty" json:",omitempty"`
-	Organization501c3Ind           CheckboxType                        `xml:"Organization501c3Ind"`
-	Organization501cInd            *Organization501cInd                `xml:"Organization501cInd,omitempty" json:",omitempty"`
-	Organization4947a1NotPFInd     CheckboxType                        `xml:"Organization4947a1NotPFInd"`
-	Organization527Ind             CheckboxType                        `xml:"Organization527Ind"`
+	Organization501c3Ind           CheckboxType                        `xml:"Organization501c3Ind,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
+	Organization501cInd            *Organization501cInd                `xml:"Organization501cInd,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
+	Organization4947a1NotPFInd     CheckboxType                        `xml:"Organization4947a1NotPFInd,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
+	Organization527Ind             CheckboxType                        `xml:"Organization527Ind,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
 	WebsiteAddressTxt              string                              `xml:"WebsiteAddressTxt,omitempty" json:",omitempty"`
 	TypeOfOrganizationCorpInd      CheckboxType                        `xml:"TypeOfOrganizationCorpInd,omitempty" json:",omitempty"`
 	TypeOfOrganizationTrustInd     CheckboxType                        `xml:"TypeOfOrganizationTrustInd,omitempty" json:",omitempty"`
@@ -32,8 +32,8 @@ type IRS990 struct {
 	TypeOfOrganizationOtherInd     CheckboxType                        `xml:"TypeOfOrganizationOtherInd,omitempty" json:",omitempty"`
 	OtherOrganizationDsc           string                              `xml:"OtherOrganizationDsc,omitempty" json:",omitempty"`
 	FormationYr                    *YearType                           `xml:"FormationYr,omitempty" json:",omitempty"`
-	LegalDomicileStateCd           StateType                           `xml:"LegalDomicileStateCd"`
-	LegalDomicileCountryCd         CountryType                         `xml:"LegalDomicileCountryCd"`
+	LegalDomicileStateCd           StateType                           `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileCountryCd         CountryType                         `xml:"LegalDomicileCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
 	ActivityOrMissionDesc          string                              `xml:"ActivityOrMissionDesc"`
 	ContractTerminationInd         CheckboxType                        `xml:"ContractTerminationInd,omitempty" json:",omitempty"`
 	VotingMembersGoverningBodyCnt  int                                 `xml:"VotingMembersGoverningBodyCnt"`
@@ -328,6 +328,10 @@ type IRS990 struct {
 	TemporarilyRstrNetAssetsGrp    *Form990PartXGroup1Type             `xml:"TemporarilyRstrNetAssetsGrp,omitempty" json:",omitempty"`
 	PermanentlyRstrNetAssetsGrp    *Form990PartXGroup1Type             `xml:"PermanentlyRstrNetAssetsGrp,omitempty" json:",omitempty"`
 	OrgDoesNotFollowSFAS117Ind     CheckboxType                        `xml:"OrgDoesNotFollowSFAS117Ind,omitempty" json:",omitempty"`
+	OrganizationFollowsFASB117Ind  CheckboxType                        `xml:"OrganizationFollowsFASB117Ind,omitempty" json:",omitempty"`
+	NoDonorRestrictionNetAssetsGrp *Form990PartXGroup1Type             `xml:"NoDonorRestrictionNetAssetsGrp,omitempty" json:",omitempty"`
+	DonorRestrictionNetAssetsGrp   *Form990PartXGroup1Type             `xml:"DonorRestrictionNetAssetsGrp,omitempty" json:",omitempty"`
+	OrgDoesNotFollowFASB117Ind     CheckboxType                        `xml:"OrgDoesNotFollowFASB117Ind,omitempty" json:",omitempty"`
 	CapStkTrPrinCurrentFundsGrp    *Form990PartXGroup1Type             `xml:"CapStkTrPrinCurrentFundsGrp,omitempty" json:",omitempty"`
 	PdInCapSrplsLandBldgEqpFundGrp *Form990PartXGroup1Type             `xml:"PdInCapSrplsLandBldgEqpFundGrp,omitempty" json:",omitempty"`
 	RtnEarnEndowmentIncmOthFndsGrp *Form990PartXGroup1Type             `xml:"RtnEarnEndowmentIncmOthFndsGrp,omitempty" json:",omitempty"`
@@ -341,9 +345,9 @@ type IRS990 struct {
 	PriorPeriodAdjustmentsAmt      int                                 `xml:"PriorPeriodAdjustmentsAmt,omitempty" json:",omitempty"`
 	OtherChangesInNetAssetsAmt     int                                 `xml:"OtherChangesInNetAssetsAmt,omitempty" json:",omitempty"`
 	InfoInScheduleOPartXIIInd      CheckboxType                        `xml:"InfoInScheduleOPartXIIInd,omitempty" json:",omitempty"`
-	MethodOfAccountingCashInd      CheckboxType                        `xml:"MethodOfAccountingCashInd"`
-	MethodOfAccountingAccrualInd   CheckboxType                        `xml:"MethodOfAccountingAccrualInd"`
-	MethodOfAccountingOtherInd     MethodOfAccountingOtherInd          `xml:"MethodOfAccountingOtherInd"`
+	MethodOfAccountingCashInd      CheckboxType                        `xml:"MethodOfAccountingCashInd,omitempty" json:",omitempty" choice:"MethodOfAccountingCashInd"`
+	MethodOfAccountingAccrualInd   CheckboxType                        `xml:"MethodOfAccountingAccrualInd,omitempty" json:",omitempty" choice:"MethodOfAccountingCashInd"`
+	MethodOfAccountingOtherInd     *MethodOfAccountingOtherInd         `xml:"MethodOfAccountingOtherInd,omitempty" json:",omitempty" choice:"MethodOfAccountingCashInd"`
 	AccountantCompileOrReviewInd   bool                                `xml:"AccountantCompileOrReviewInd"`
 	AcctCompileOrReviewBasisGrp    *FinancialStatementType             `xml:"AcctCompileOrReviewBasisGrp,omitempty" json:",omitempty"`
 	FSAuditedInd                   bool                                `xml:"FSAuditedInd"`
@@ -367,18 +371,18 @@ type IRS990Type struct {
 	FinalReturnInd                 CheckboxType                        `xml:"FinalReturnInd,omitempty" json:",omitempty"`
 	AmendedReturnInd               CheckboxType                        `xml:"AmendedReturnInd,omitempty" json:",omitempty"`
 	DoingBusinessAsName            *BusinessNameType                   `xml:"DoingBusinessAsName,omitempty" json:",omitempty"`
-	PrincipalOfficerNm             PersonNameType                      `xml:"PrincipalOfficerNm"`
-	PrincipalOfcrBusinessName      BusinessNameType                    `xml:"PrincipalOfcrBusinessName"`
-	USAddress                      USAddressType                       `xml:"USAddress"`
-	ForeignAddress                 ForeignAddressType                  `xml:"ForeignAddress"`
+	PrincipalOfficerNm             PersonNameType                      `xml:"PrincipalOfficerNm,omitempty" json:",omitempty" choice:"PrincipalOfficerNm"`
+	PrincipalOfcrBusinessName      *BusinessNameType                   `xml:"PrincipalOfcrBusinessName,omitempty" json:",omitempty" choice:"PrincipalOfficerNm"`
+	USAddress                      *USAddressType                      `xml:"USAddress,omitempty" json:",omitempty" choice:"USAddress"`
+	ForeignAddress                 *ForeignAddressType                 `xml:"ForeignAddress,omitempty" json:",omitempty" choice:"USAddress"`
 	GrossReceiptsAmt               int                                 `xml:"GrossReceiptsAmt"`
 	GroupReturnForAffiliatesInd    bool                                `xml:"GroupReturnForAffiliatesInd"`
 	AllAffiliatesIncludedInd       *AllAffiliatesIncludedInd           `xml:"AllAffiliatesIncludedInd,omitempty" json:",omitempty"`
 	GroupExemptionNum              *GroupExemptionNum                  `xml:"GroupExemptionNum,omitempty" json:",omitempty"`
-	Organization501c3Ind           CheckboxType                        `xml:"Organization501c3Ind"`
-	Organization501cInd            *Organization501cInd                `xml:"Organization501cInd,omitempty" json:",omitempty"`
-	Organization4947a1NotPFInd     CheckboxType                        `xml:"Organization4947a1NotPFInd"`
-	Organization527Ind             CheckboxType                        `xml:"Organization527Ind"`
+	Organization501c3Ind           CheckboxType                        `xml:"Organization501c3Ind,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
+	Organization501cInd            *Organization501cInd                `xml:"Organization501cInd,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
+	Organization4947a1NotPFInd     CheckboxType                        `xml:"Organization4947a1NotPFInd,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
+	Organization527Ind             CheckboxType                        `xml:"Organization527Ind,omitempty" json:",omitempty" choice:"Organization501c3Ind"`
 	WebsiteAddressTxt              string                              `xml:"WebsiteAddressTxt,omitempty" json:",omitempty"`
 	TypeOfOrganizationCorpInd      CheckboxType                        `xml:"TypeOfOrganizationCorpInd,omitempty" json:",omitempty"`
 	TypeOfOrganizationTrustInd     CheckboxType                        `xml:"TypeOfOrganizationTrustInd,omitempty" json:",omitempty"`
@@ -386,8 +390,8 @@ type IRS990Type struct {
 	TypeOfOrganizationOtherInd     CheckboxType                        `xml:"TypeOfOrganizationOtherInd,omitempty" json:",omitempty"`
 	OtherOrganizationDsc           string                              `xml:"OtherOrganizationDsc,omitempty" json:",omitempty"`
 	FormationYr                    *YearType                           `xml:"FormationYr,omitempty" json:",omitempty"`
-	LegalDomicileStateCd           StateType                           `xml:"LegalDomicileStateCd"`
-	LegalDomicileCountryCd         CountryType                         `xml:"LegalDomicileCountryCd"`
+	LegalDomicileStateCd           StateType                           `xml:"LegalDomicileStateCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
+	LegalDomicileCountryCd         CountryType                         `xml:"LegalDomicileCountryCd,omitempty" json:",omitempty" choice:"LegalDomicileStateCd"`
 	ActivityOrMissionDesc          string                              `xml:"ActivityOrMissionDesc"`
 	ContractTerminationInd         CheckboxType                        `xml:"ContractTerminationInd,omitempty" json:",omitempty"`
 	VotingMembersGoverningBodyCnt  int                                 `xml:"VotingMembersGoverningBodyCnt"`
@@ -682,6 +686,10 @@ type IRS990Type struct {
 	TemporarilyRstrNetAssetsGrp    *Form990PartXGroup1Type             `xml:"TemporarilyRstrNetAssetsGrp,omitempty" json:",omitempty"`
 	PermanentlyRstrNetAssetsGrp    *Form990PartXGroup1Type             `xml:"PermanentlyRstrNetAssetsGrp,omitempty" json:",omitempty"`
 	OrgDoesNotFollowSFAS117Ind     CheckboxType                        `xml:"OrgDoesNotFollowSFAS117Ind,omitempty" json:",omitempty"`
+	OrganizationFollowsFASB117Ind  CheckboxType                        `xml:"OrganizationFollowsFASB117Ind,omitempty" json:",omitempty"`
+	NoDonorRestrictionNetAssetsGrp *Form990PartXGroup1Type             `xml:"NoDonorRestrictionNetAssetsGrp,omitempty" json:",omitempty"`
+	DonorRestrictionNetAssetsGrp   *Form990PartXGroup1Type             `xml:"DonorRestrictionNetAssetsGrp,omitempty" json:",omitempty"`
+	OrgDoesNotFollowFASB117Ind     CheckboxType                        `xml:"OrgDoesNotFollowFASB117Ind,omitempty" json:",omitempty"`
 	CapStkTrPrinCurrentFundsGrp    *Form990PartXGroup1Type             `xml:"CapStkTrPrinCurrentFundsGrp,omitempty" json:",omitempty"`
 	PdInCapSrplsLandBldgEqpFundGrp *Form990PartXGroup1Type             `xml:"PdInCapSrplsLandBldgEqpFundGrp,omitempty" json:",omitempty"`
 	RtnEarnEndowmentIncmOthFndsGrp *Form990PartXGroup1Type             `xml:"RtnEarnEndowmentIncmOthFndsGrp,omitempty" json:",omitempty"`
@@ -695,9 +703,9 @@ type IRS990Type struct {
 	PriorPeriodAdjustmentsAmt      int                                 `xml:"PriorPeriodAdjustmentsAmt,omitempty" json:",omitempty"`
 	OtherChangesInNetAssetsAmt     int                                 `xml:"OtherChangesInNetAssetsAmt,omitempty" json:",omitempty"`
 	InfoInScheduleOPartXIIInd      CheckboxType                        `xml:"InfoInScheduleOPartXIIInd,omitempty" json:",omitempty"`
-	MethodOfAccountingCashInd      CheckboxType                        `xml:"MethodOfAccountingCashInd"`
-	MethodOfAccountingAccrualInd   CheckboxType                        `xml:"MethodOfAccountingAccrualInd"`
-	MethodOfAccountingOtherInd     MethodOfAccountingOtherInd          `xml:"MethodOfAccountingOtherInd"`
+	MethodOfAccountingCashInd      CheckboxType                        `xml:"MethodOfAccountingCashInd,omitempty" json:",omitempty" choice:"MethodOfAccountingCashInd"`
+	MethodOfAccountingAccrualInd   CheckboxType                        `xml:"MethodOfAccountingAccrualInd,omitempty" json:",omitempty" choice:"MethodOfAccountingCashInd"`
+	MethodOfAccountingOtherInd     *MethodOfAccountingOtherInd         `xml:"MethodOfAccountingOtherInd,omitempty" json:",omitempty" choice:"MethodOfAccountingCashInd"`
 	AccountantCompileOrReviewInd   bool                                `xml:"AccountantCompileOrReviewInd"`
 	AcctCompileOrReviewBasisGrp    *FinancialStatementType             `xml:"AcctCompileOrReviewBasisGrp,omitempty" json:",omitempty"`
 	FSAuditedInd                   bool                                `xml:"FSAuditedInd"`
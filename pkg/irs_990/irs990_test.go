@@ -6,14 +6,25 @@ package irs_990
 
 import (
 	"archive/zip"
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/moov-io/1120x/pkg/utils"
 )
 
 func TestReturnXmlTest(t *testing.T) {
@@ -146,6 +157,17 @@ func Test990FileTest(t *testing.T) {
 	err = xml.Unmarshal(manifestBuf, file.Manifest)
 	assert.Equal(t, nil, err)
 
+	// a test EIN is only allowed in a test submission
+	err = file.Validate()
+	assert.Equal(t, nil, err)
+	ein := file.XmlData.ReturnHeader.Filer.EIN
+	file.XmlData.ReturnHeader.Filer.EIN, file.Manifest.TIN = "001234567", "001234567"
+	assert.True(t, errors.Is(file.XmlData.Validate(), utils.ErrTestIdentifier))
+	assert.True(t, errors.Is(file.Validate(), utils.ErrTestIdentifier))
+	file.TestCd = utils.TestCd
+	assert.Equal(t, nil, file.Validate())
+	file.XmlData.ReturnHeader.Filer.EIN, file.Manifest.TIN = ein, ein
+
 	// 2. struct to xml buf
 	xmlOrgBuf, err := xml.MarshalIndent(file, "", "\t")
 	assert.Equal(t, nil, err)
@@ -172,7 +194,7 @@ func Test990FileTest(t *testing.T) {
 	assert.Equal(t, nil, err)
 
 	version := newFile.Version()
-	assert.Equal(t, "2019v1.0", version)
+	assert.Equal(t, "2019v5.0", version)
 
 	zipData, err := newFile.ZipData()
 	assert.Equal(t, nil, err)
@@ -516,3 +538,1058 @@ func TestUnusedStructs(t *testing.T) {
 		_time.MarshalXMLAttr(xml.Name{Local: "test"})
 	}
 }
+
+func TestAddressCodeListTest(t *testing.T) {
+	us := USAddressType{
+		AddressLine1Txt:     "1 Main St",
+		CityNm:              "Boston",
+		StateAbbreviationCd: "MA",
+		ZIPCd:               "02110",
+	}
+	assert.Equal(t, nil, us.Validate())
+	us.StateAbbreviationCd = "AE"
+	assert.Equal(t, nil, us.Validate())
+	us.StateAbbreviationCd = "ZZ"
+	assert.NotNil(t, us.Validate())
+
+	foreign := ForeignAddressType{
+		AddressLine1Txt: "1 Queen St",
+		CountryCd:       "CA",
+	}
+	assert.Equal(t, nil, foreign.Validate())
+	foreign.CountryCd = "US"
+	assert.NotNil(t, foreign.Validate())
+	foreign.CountryCd = "UC"
+	assert.Equal(t, nil, foreign.Validate())
+	foreign.CountryCd = "ZZ"
+	assert.NotNil(t, foreign.Validate())
+
+	assert.Equal(t, nil, AllCountriesType("US").Validate())
+	assert.Equal(t, nil, AllStatesCd("All States").Validate())
+	assert.NotNil(t, AllStatesCd("ZZ").Validate())
+
+	// codes are checked against the code list of the tax year of the return
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	returnData := &Return{}
+	assert.Equal(t, nil, xml.Unmarshal(InputXML, returnData))
+	assert.Equal(t, nil, returnData.ValidateCodeLists())
+
+	address := returnData.ReturnData.IRS990.BooksInCareOfDetail.ForeignAddress
+	address.CountryCd = "GP"
+	assert.Equal(t, nil, address.Validate())
+	assert.Equal(t, nil, returnData.ValidateCodeLists())
+	returnData.ReturnHeader.TaxYr = YearType(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(returnData.ValidateCodeLists(), ErrCodeNotInTaxYr))
+	address.CountryCd = "CA"
+	assert.Equal(t, nil, returnData.ValidateCodeLists())
+}
+
+func TestRequiredElementTest(t *testing.T) {
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+
+	returnData := &Return{}
+	err = xml.Unmarshal(InputXML, returnData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, returnData.Validate())
+
+	// missing required elements
+	softwareId := returnData.ReturnHeader.SoftwareId
+	returnData.ReturnHeader.SoftwareId = ""
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingElement))
+	assert.Contains(t, err.Error(), "ReturnHeaderType.SoftwareId")
+	returnData.ReturnHeader.SoftwareId = softwareId
+
+	irs990 := returnData.ReturnData.IRS990
+	returnData.ReturnData.IRS990 = nil
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingElement))
+	assert.Contains(t, err.Error(), "ReturnData.IRS990")
+	returnData.ReturnData.IRS990 = irs990
+
+	filer := returnData.ReturnHeader.Filer
+	returnData.ReturnHeader.Filer = Filer{}
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingElement))
+	assert.Contains(t, err.Error(), "ReturnHeaderType.Filer")
+	returnData.ReturnHeader.Filer = filer
+
+	// missing required choice
+	returnData.ReturnHeader.Filer.USAddress = nil
+	returnData.ReturnHeader.Filer.ForeignAddress = nil
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	returnData.ReturnHeader.Filer.USAddress = filer.USAddress
+	assert.Equal(t, nil, returnData.Validate())
+
+	// missing principal officer and address
+	officer := irs990.PrincipalOfficerNm
+	irs990.PrincipalOfficerNm = ""
+	irs990.PrincipalOfcrBusinessName = nil
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "IRS990.(PrincipalOfficerNm|PrincipalOfcrBusinessName)")
+	irs990.PrincipalOfficerNm = officer
+
+	usAddress, foreignAddress := irs990.USAddress, irs990.ForeignAddress
+	irs990.USAddress = nil
+	irs990.ForeignAddress = nil
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "IRS990.(USAddress|ForeignAddress)")
+	irs990.USAddress, irs990.ForeignAddress = usAddress, foreignAddress
+	assert.Equal(t, nil, returnData.Validate())
+
+	// empty optional elements
+	returnData.ReturnHeader.Filer.PhoneNum = nil
+	returnData.ReturnHeader.Filer.InCareOfNm = nil
+	assert.Equal(t, nil, returnData.Validate())
+
+	buf, err := xml.Marshal(returnData.ReturnHeader.Filer)
+	assert.Equal(t, nil, err)
+	assert.NotContains(t, string(buf), "PhoneNum")
+	assert.NotContains(t, string(buf), "ForeignAddress")
+	assert.Contains(t, string(buf), "USAddress")
+}
+
+func TestRequiredChoiceTest(t *testing.T) {
+	address := &USAddressType{AddressLine1Txt: "1 Main St", CityNm: "Anytown", StateAbbreviationCd: "CA", ZIPCd: "90210"}
+	fundraiser := FundraiserActivityInfoGrpType{PersonNm: "John Doe", USAddress: address}
+	assert.Equal(t, nil, fundraiser.Validate())
+
+	// missing name
+	fundraiser.PersonNm = ""
+	err := fundraiser.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "FundraiserActivityInfoGrpType.(PersonNm|OrganizationBusinessName)")
+	fundraiser.OrganizationBusinessName = &BusinessNameType{BusinessNameLine1Txt: "Fundraising Inc"}
+	assert.Equal(t, nil, fundraiser.Validate())
+
+	// missing address
+	fundraiser.USAddress = nil
+	err = fundraiser.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "FundraiserActivityInfoGrpType.(USAddress|ForeignAddress)")
+
+	contractor := Form990PartVIIGroup1Type{ContractorName: &ContractorName{}, ContractorAddress: &ContractorAddress{USAddress: address}}
+	err = contractor.ContractorName.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "ContractorName.(PersonNm|BusinessName)")
+	contractor.ContractorName.BusinessName = &BusinessNameType{BusinessNameLine1Txt: "Contractor Inc"}
+	assert.Equal(t, nil, contractor.ContractorName.Validate())
+	contractor.ContractorAddress.USAddress = nil
+	err = contractor.ContractorAddress.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "ContractorAddress.(USAddress|ForeignAddress)")
+
+	contributor := ContributorInformationGrpType{ContributorNum: 1, Paid527j1Ind: "X", ContributorUSAddress: address}
+	assert.Equal(t, nil, contributor.Validate())
+	contributor.Paid527j1Ind = ""
+	err = contributor.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "ContributorInformationGrpType.(ContributorBusinessName|ContributorPersonNm|Paid527j1Ind)")
+
+	// the supporting organization type is only required for a supporting organization
+	schedule := IRS990ScheduleAType{ChurchInd: "X"}
+	assert.Equal(t, nil, schedule.Validate())
+	schedule = IRS990ScheduleAType{SupportingOrganization509a3Ind: "X"}
+	err = schedule.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "IRS990ScheduleAType.(SupportingOrgType1Ind|SupportingOrgType2Ind|SupportingOrgType3FuncIntInd|SupportingOrgType3NonFuncInd)")
+	schedule.SupportingOrgType1Ind = "X"
+	assert.Equal(t, nil, schedule.Validate())
+
+	// the third party is only required when the organization contracted with one
+	gaming := IRS990ScheduleGType{}
+	assert.Equal(t, nil, gaming.Validate())
+	gaming.CntrctWith3rdPrtyForGameRevInd = true
+	err = gaming.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "IRS990ScheduleGType.(ThirdPartyPersonNm|ThirdPartyBusinessName)")
+	gaming.CntrctWith3rdPrtyForGameRevInd = false
+	assert.Equal(t, nil, gaming.Validate())
+
+	// optional choices allow one element at most
+	gaming.LicensedStatesCd = []StateType{"CA"}
+	gaming.AllStatesCd = "All States"
+	err = gaming.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMultipleChoice))
+	assert.Contains(t, err.Error(), "IRS990ScheduleGType.(LicensedStatesCd|AllStatesCd)")
+	gaming.LicensedStatesCd = nil
+	assert.Equal(t, nil, gaming.Validate())
+
+	hospitals := IRS990ScheduleHType{HospitalFacilitiesGrp: []HospitalFacilitiesGrp{{FacilityNum: 1}}, FPGReferenceFreeCareInd: true}
+	err = hospitals.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingChoice))
+	assert.Contains(t, err.Error(), "IRS990ScheduleHType.(Percent100Ind|Percent150Ind|Percent200Ind|FreeCareOthPercentageGrp)")
+	hospitals.Percent200Ind = "X"
+	assert.Equal(t, nil, hospitals.Validate())
+}
+
+func TestConsistencyTest(t *testing.T) {
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+
+	returnData := &Return{}
+	err = xml.Unmarshal(InputXML, returnData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 6, len(returnData.ReturnData.Documents()))
+	assert.Equal(t, nil, returnData.ValidateConsistency())
+
+	returnData.ReturnData.DocumentCnt = 5
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, ErrMismatchedDocumentCnt))
+
+	attachment := BinaryAttachment{
+		DocumentTypeCd:        "PDF",
+		Desc:                  "GENERAL EXPLANATION ATTACHMENT",
+		AttachmentLocationTxt: "attachment/explanation.pdf",
+		DocumentId:            "RetDoc1038000002",
+	}
+	returnData.ReturnData.BinaryAttachment = append(returnData.ReturnData.BinaryAttachment, attachment)
+	returnData.FixConsistency()
+	assert.Equal(t, 7, returnData.ReturnData.DocumentCnt)
+	assert.Equal(t, 1, returnData.ReturnHeader.BinaryAttachmentCnt)
+	assert.Equal(t, nil, returnData.ValidateConsistency())
+
+	returnData.ReturnHeader.BinaryAttachmentCnt = 0
+	err = returnData.ValidateConsistency()
+	assert.True(t, errors.Is(err, ErrMismatchedBinaryAttachmentCnt))
+	returnData.FixConsistency()
+
+	returnData.ReturnData.BinaryAttachment[0].DocumentId = "RetDoc1038000001"
+	err = returnData.ValidateConsistency()
+	assert.True(t, errors.Is(err, ErrDuplicatedDocumentId))
+	returnData.ReturnData.BinaryAttachment[0].DocumentId = "RetDoc1038000002"
+
+	err = returnData.ValidateAttachmentLocations([]string{"xml/submission.xml", "manifest/manifest.xml"})
+	assert.True(t, errors.Is(err, ErrUnresolvedAttachmentLocation))
+	err = returnData.ValidateAttachmentLocations([]string{"xml/submission.xml", "attachment/explanation.pdf"})
+	assert.Equal(t, nil, err)
+
+	file := Irs990File{XmlData: *returnData}
+	err = file.XmlData.ValidateAttachmentLocations(file.ZipFiles())
+	assert.True(t, errors.Is(err, ErrUnresolvedAttachmentLocation))
+}
+
+func TestNameControlTest(t *testing.T) {
+	businessNames := map[BusinessNameLine1Type]BusinessNameControlType{
+		"VOICE OF SAN DIEGO":         "VOIC",
+		"The Hawthorn Company":       "HAWT",
+		"The Willows":                "THEW",
+		"A & B Company":              "A&BC",
+		"K-F Tire Center":            "K-FT",
+		"13th Street Inc":            "13TH",
+		"St. Paul's Church":          "STPA",
+		"Y.M.C.A. of Bell":           "YMCA",
+		"The Friends of the Library": "FRIE",
+		"IBM":                        "IBM",
+	}
+	for name, control := range businessNames {
+		assert.Equal(t, control, BusinessNameControl(name), string(name))
+	}
+
+	lastNames := map[PersonLastNameType]PersonNameControlType{
+		"Lewis":       "LEWI",
+		"O'Neil":      "ONEI",
+		"De La Rosa":  "DELA",
+		"Ra-Smith":    "RA-S",
+		"Smith-Jones": "SMIT",
+		"Ng":          "NG",
+	}
+	for name, control := range lastNames {
+		assert.Equal(t, control, PersonNameControl(name), string(name))
+	}
+
+	filer := Filer{
+		BusinessName:           BusinessNameType{BusinessNameLine1Txt: "VOICE OF SAN DIEGO"},
+		BusinessNameControlTxt: "VOIC",
+	}
+	assert.Equal(t, 0, len(filer.NameControlWarnings()))
+	filer.BusinessNameControlTxt = "THEV"
+	warnings := filer.NameControlWarnings()
+	assert.Equal(t, 1, len(warnings))
+	assert.True(t, errors.Is(warnings[0], ErrMismatchedNameControl))
+
+	returnData := &Return{ReturnHeader: ReturnHeaderType{Filer: filer}}
+	assert.Equal(t, 1, len(returnData.Warnings()))
+
+	manifest := StateSubmissionManifest{
+		BusinessNameControlTxt: "VOIC",
+		PrimaryNameControlTxt:  "LEWI",
+		SpouseNameControlTxt:   "SMIT",
+	}
+	names := NameControlNames{BusinessName: "VOICE OF SAN DIEGO", PrimaryLastName: "Lewis"}
+	assert.Equal(t, 0, len(manifest.NameControlWarnings(names)))
+	names.SpouseLastName = "Jones"
+	names.PrimaryLastName = "Lee"
+	assert.Equal(t, 2, len(manifest.NameControlWarnings(names)))
+}
+
+func TestIdentifierTest(t *testing.T) {
+	assert.True(t, IsValidEIN("201585919"))
+	assert.True(t, IsValidEIN("001234567"))
+	assert.True(t, IsTestEIN("001234567"))
+	assert.False(t, IsTestEIN("201585919"))
+	assert.False(t, IsValidEIN("071234567"))
+	assert.False(t, IsValidEIN("961234567"))
+	assert.False(t, IsValidEIN("2015859190"))
+	assert.NotNil(t, EINType("891234567").Validate())
+	assert.Equal(t, nil, EINType("981234567").Validate())
+
+	assert.True(t, IsValidSSN("123456789"))
+	assert.False(t, IsValidSSN("000456789"))
+	assert.False(t, IsValidSSN("666456789"))
+	assert.False(t, IsValidSSN("912456789"))
+	assert.False(t, IsValidSSN("123006789"))
+	assert.False(t, IsValidSSN("123450000"))
+	assert.False(t, IsValidSSN("12345678"))
+
+	assert.True(t, IsValidITIN("912501234"))
+	assert.True(t, IsValidITIN("912781234"))
+	assert.True(t, IsValidITIN("912941234"))
+	assert.False(t, IsValidITIN("912931234"))
+	assert.False(t, IsValidITIN("912661234"))
+	assert.False(t, IsValidITIN("812501234"))
+	assert.Equal(t, nil, SSNType("912501234").Validate())
+	assert.NotNil(t, SSNType("912001234").Validate())
+
+	assert.True(t, IsValidPTIN("P00735101"))
+	assert.False(t, IsValidPTIN("P00000000"))
+	assert.False(t, IsValidPTIN("00735101"))
+	assert.False(t, IsValidPTIN("P007351011"))
+
+	assert.True(t, IsValidEFIN("123456"))
+	assert.False(t, IsValidEFIN("12345"))
+	assert.True(t, IsValidETIN("12345"))
+	assert.False(t, IsValidETIN("123456"))
+
+	header := ReturnHeaderType{Filer: Filer{EIN: "001234567"}}
+	err := utils.ValidateProductionIdentifiers(&header)
+	assert.True(t, errors.Is(err, utils.ErrTestIdentifier))
+	header.Filer.EIN = "201585919"
+	header.OriginatorGrp.PractitionerPINGrp = &PractitionerPINGrp{EFIN: "123456"}
+	assert.Equal(t, nil, utils.ValidateProductionIdentifiers(&header))
+}
+
+func TestBankAccountTest(t *testing.T) {
+	assert.True(t, IsValidRoutingNumber("011000015"))
+	assert.True(t, IsValidRoutingNumber("021000021"))
+	assert.True(t, IsValidRoutingNumber("322271627"))
+	assert.False(t, IsValidRoutingNumber("021000022"))
+	assert.False(t, IsValidRoutingNumber("131000026"))
+	assert.False(t, IsValidRoutingNumber("000000000"))
+	assert.False(t, IsValidRoutingNumber("0210000210"))
+	assert.Equal(t, nil, RoutingTransitNumberType("021000021").Validate())
+	assert.NotNil(t, RoutingTransitNumberType("021000022").Validate())
+
+	assert.True(t, IsValidBankAccountNumber("1234-5678-A"))
+	assert.True(t, IsValidBankAccountNumber("ABCDEF"))
+	assert.False(t, IsValidBankAccountNumber("000-000"))
+	assert.False(t, IsValidBankAccountNumber("123456789012345678"))
+	assert.False(t, IsValidBankAccountNumber("1234 5678"))
+
+	assert.Equal(t, nil, ValidateDirectDebit("021000021", "1234567890", "1"))
+	assert.NotNil(t, ValidateDirectDebit("021000021", "1234567890", "3"))
+	assert.NotNil(t, ValidateDirectDebit("021000021", "", "1"))
+	assert.NotNil(t, ValidateDirectDebit("021000022", "1234567890", "2"))
+
+	payment := IRSPayment{
+		RoutingTransitNum:       "021000021",
+		BankAccountNum:          "1234567890",
+		BankAccountTypeCd:       "1",
+		PaymentAmt:              100,
+		RequestedPaymentDt:      DateType(time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC)),
+		TaxpayerDaytimePhoneNum: "2025550100",
+		DocumentId:              "IRSPayment1",
+	}
+	assert.Equal(t, nil, payment.Validate())
+	payment.BankAccountNum = ""
+	assert.NotNil(t, payment.Validate())
+	payment.BankAccountNum = "000-000"
+	assert.NotNil(t, payment.Validate())
+}
+
+func TestTaxPeriodTest(t *testing.T) {
+	date := func(value string) DateType {
+		dt, err := time.Parse("2006-01-02", value)
+		assert.Equal(t, nil, err)
+		return DateType(dt)
+	}
+	year := func(value int) YearType {
+		return YearType(time.Date(value, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	assert.Equal(t, nil, ValidateTaxPeriod(date("2019-01-01"), date("2019-12-31")))
+	assert.Equal(t, nil, ValidateTaxPeriod(date("2019-07-01"), date("2020-06-30")))
+	assert.Equal(t, nil, ValidateTaxPeriod(date("2019-03-01"), date("2019-06-30")))
+	// 52-53 week years
+	assert.Equal(t, nil, ValidateTaxPeriod(date("2019-09-01"), date("2020-08-29")))
+	assert.Equal(t, nil, ValidateTaxPeriod(date("2019-09-01"), date("2020-09-05")))
+	assert.True(t, errors.Is(ValidateTaxPeriod(date("2019-01-01"), date("2020-01-01")), ErrInvalidTaxPeriod))
+	assert.True(t, errors.Is(ValidateTaxPeriod(date("2019-12-31"), date("2019-01-01")), ErrInvalidTaxPeriod))
+
+	assert.Equal(t, nil, ValidateTaxYr(year(2019), date("2019-07-01")))
+	assert.True(t, errors.Is(ValidateTaxYr(year(2020), date("2019-07-01")), ErrMismatchedTaxYr))
+
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	returnData := &Return{}
+	err = xml.Unmarshal(InputXML, returnData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, returnData.ValidateDates())
+
+	returnData.ReturnHeader.ReturnTs = TimestampType(time.Now().Add(time.Hour))
+	assert.True(t, errors.Is(returnData.Validate(), ErrFutureReturnTs))
+	returnData.ReturnHeader.ReturnTs = TimestampType(time.Now())
+
+	returnData.Version = "2018v3.3"
+	assert.True(t, errors.Is(returnData.Validate(), ErrUnacceptedTaxYr))
+	returnData.Version = "2019v1.0"
+	assert.True(t, errors.Is(returnData.ValidateDates(), ErrUnknownReturnVersion))
+	returnData.Version = "2019v5.0"
+
+	// a version accepts the fiscal years that begin in its tax year and the short periods of the next year
+	version, err := LookupVersion("2019v5.0")
+	assert.Equal(t, nil, err)
+	assert.True(t, version.AcceptsTaxPeriod(date("2019-01-01"), date("2019-12-31")))
+	assert.True(t, version.AcceptsTaxPeriod(date("2019-07-01"), date("2020-06-30")))
+	assert.True(t, version.AcceptsTaxPeriod(date("2020-01-01"), date("2020-06-30")))
+	assert.False(t, version.AcceptsTaxPeriod(date("2020-01-01"), date("2020-12-31")))
+	assert.False(t, version.AcceptsTaxPeriod(date("2018-07-01"), date("2019-06-30")))
+	assert.True(t, errors.Is(version.ValidateTaxPeriod(date("2021-01-01"), date("2021-03-31")), ErrUnacceptedTaxYr))
+
+	manifest := IRSSubmissionManifest{}
+	assert.Equal(t, nil, manifest.ValidateDates(returnData.ReturnHeader))
+	taxYr, begin, end := year(2019), date("2019-01-01"), date("2019-12-31")
+	manifest.TaxYr, manifest.TaxPeriodBeginDt, manifest.TaxPeriodEndDt = &taxYr, &begin, &end
+	assert.Equal(t, nil, manifest.ValidateDates(returnData.ReturnHeader))
+	end = date("2019-11-30")
+	assert.True(t, errors.Is(manifest.ValidateDates(returnData.ReturnHeader), ErrMismatchedManifestPeriod))
+
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+	file := Irs990File{XmlData: *returnData, Manifest: &IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	err = xml.Unmarshal(manifestBuf, file.Manifest)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, file.Validate())
+	file.Manifest.TaxPeriodEndDt = &end
+	assert.True(t, errors.Is(file.Validate(), ErrMismatchedManifestPeriod))
+}
+
+func TestVersionTest(t *testing.T) {
+	version, err := LookupVersion(Version)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2015, version.TaxYr)
+	assert.Equal(t, "2015", version.StylesheetDir)
+	assert.Equal(t, "efile990x_2015v2.0_09082015", version.SchemaPackage)
+	for _, registered := range []string{"2016v3.1", "2017v2.3", "2018v3.3", "2019v5.2", "2020v4.2"} {
+		assert.Contains(t, Versions(), registered)
+	}
+	version, err = LookupVersion("2018v3.0")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2018, version.TaxYr)
+	assert.Equal(t, "2018", version.StylesheetDir)
+	assert.Equal(t, "efile990x_2018v3.0", version.SchemaPackage)
+	_, err = LookupVersion("2019v1.0")
+	assert.True(t, errors.Is(err, ErrUnknownReturnVersion))
+
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	returnData := &Return{}
+	err = xml.Unmarshal(InputXML, returnData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2019, returnData.ReturnYear())
+	assert.Equal(t, "2019", returnData.StylesheetDir())
+	assert.Equal(t, nil, returnData.ValidateVersion())
+
+	// the SFAS 117 net assets were replaced by the donor restriction net assets in 2018
+	irs990 := returnData.ReturnData.IRS990
+	irs990.OrganizationFollowsSFAS117Ind = "X"
+	assert.True(t, errors.Is(returnData.ValidateVersion(), utils.ErrUnsupportedElement))
+	assert.Contains(t, returnData.ValidateVersion().Error(), "IRS990/OrganizationFollowsSFAS117Ind")
+	irs990.OrganizationFollowsSFAS117Ind = ""
+	returnData.Version = "2017v2.3"
+	assert.True(t, errors.Is(returnData.ValidateVersion(), utils.ErrUnsupportedElement))
+	irs990.UnrestrictedNetAssetsGrp, irs990.NoDonorRestrictionNetAssetsGrp = irs990.NoDonorRestrictionNetAssetsGrp, nil
+	irs990.OrganizationFollowsSFAS117Ind, irs990.OrganizationFollowsFASB117Ind = "X", ""
+	assert.Equal(t, nil, returnData.ValidateVersion())
+
+	// versions added to the registry
+	t.Cleanup(func() { UnregisterVersion("2021v4.2") })
+	err = RegisterVersion(SchemaVersion{Version: "2021v4.2", TaxYr: 2021, StylesheetDir: "2021", UnsupportedElements: sfas117Elements})
+	assert.Equal(t, nil, err)
+	returnData.Version = "2021v4.2"
+	assert.Equal(t, 2021, returnData.ReturnYear())
+	assert.True(t, errors.Is(returnData.ValidateVersion(), utils.ErrUnsupportedElement))
+	UnregisterVersion("2021v4.2")
+	assert.Equal(t, 0, returnData.ReturnYear())
+	assert.True(t, errors.Is(returnData.Validate(), ErrUnknownReturnVersion))
+
+	// the version of the structs can't be removed
+	UnregisterVersion(Version)
+	_, err = LookupVersion(Version)
+	assert.Equal(t, nil, err)
+}
+
+func TestManifestReturnTest(t *testing.T) {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &Irs990File{Manifest: &IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+	assert.Equal(t, nil, file.Manifest.ValidateReturn(file.XmlData.ReturnHeader))
+	_, err = file.ZipData()
+	assert.Equal(t, nil, err)
+
+	file.Manifest.TIN = "000000001"
+	assert.True(t, errors.Is(file.Validate(), ErrMismatchedManifest))
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrMismatchedManifest))
+	file.Manifest.TIN = file.XmlData.ReturnHeader.Filer.EIN
+
+	file.Manifest.EFIN = "000001"
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrMismatchedManifest))
+	file.Manifest.EFIN = file.XmlData.ReturnHeader.OriginatorGrp.EFIN
+
+	file.Manifest.FederalSubmissionTypeCd = "990EZ"
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrMismatchedManifest))
+	file.Manifest.FederalSubmissionTypeCd = "990"
+
+	taxYr := YearType(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	file.Manifest.TaxYr = &taxYr
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrMismatchedManifestPeriod))
+}
+
+func TestSeverityTest(t *testing.T) {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &Irs990File{Manifest: &IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+	assert.Equal(t, 0, len(file.ValidationResult().Issues))
+
+	// alerts don't block unless chosen
+	file.XmlData.ReturnHeader.Filer.BusinessNameControlTxt = "ABCD"
+	result := file.ValidationResult()
+	assert.Equal(t, 0, len(result.Rejects()))
+	assert.Equal(t, 1, len(result.Alerts()))
+	assert.True(t, errors.Is(result.Alerts()[0], ErrMismatchedNameControl))
+	_, err = file.ZipData()
+	assert.Equal(t, nil, err)
+	file.BlockingSeverities = []utils.Severity{utils.SeverityAlert}
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrMismatchedNameControl))
+
+	// rejects always block
+	ein := file.XmlData.ReturnHeader.Filer.EIN
+	file.XmlData.ReturnHeader.Filer.EIN, file.Manifest.TIN = "001234567", "001234567"
+	file.TestCd = utils.ProductionCd
+	file.BlockingSeverities = nil
+	result = file.ValidationResult()
+	assert.Equal(t, 1, len(result.Rejects()))
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, utils.ErrTestIdentifier))
+	file.BlockingSeverities = []utils.Severity{utils.SeverityRejectAndStop}
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, utils.ErrTestIdentifier))
+	assert.True(t, errors.Is(result.Err(utils.SeverityAlert), utils.ErrTestIdentifier))
+
+	errorList, alertList := ValidationLists(result, "RetDoc1")
+	assert.Equal(t, 1, errorList.ErrorCnt)
+	assert.Equal(t, "Reject", errorList.ValidationErrorGrp[0].SeverityCd)
+	assert.Equal(t, 1, alertList.AlertCnt)
+	assert.Equal(t, "Data Mismatch", alertList.ValidationAlertGrp[0].AlertCategoryCd)
+
+	ack := Acknowledgement{ValidationErrorList: errorList, ValidationAlertList: alertList}
+	ackResult := ack.ValidationResult()
+	assert.Equal(t, 1, len(ackResult.Rejects()))
+	assert.Equal(t, 1, len(ackResult.Alerts()))
+	file.XmlData.ReturnHeader.Filer.EIN, file.Manifest.TIN = ein, ein
+
+	// a mismatched manifest always blocks
+	file.Manifest.TIN = "000000001"
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrMismatchedManifest))
+	file.Manifest.TIN = file.XmlData.ReturnHeader.Filer.EIN
+
+	// every invalid element is reported and stops the business rules
+	file.TestCd = utils.TestCd
+	softwareId := file.XmlData.ReturnHeader.SoftwareId
+	file.XmlData.ReturnHeader.SoftwareId = ""
+	officer, officerBusiness := file.XmlData.ReturnData.IRS990.PrincipalOfficerNm, file.XmlData.ReturnData.IRS990.PrincipalOfcrBusinessName
+	file.XmlData.ReturnData.IRS990.PrincipalOfficerNm, file.XmlData.ReturnData.IRS990.PrincipalOfcrBusinessName = "", nil
+	file.XmlData.ReturnHeader.ReturnTs = TimestampType(time.Now().AddDate(1, 0, 0))
+	result = file.ValidationResult()
+	assert.Equal(t, 2, len(result.Rejects()))
+	assert.Equal(t, utils.SeverityRejectAndStop, result.Issues[0].Severity)
+	assert.True(t, errors.Is(result.Issues[0], utils.ErrMissingElement))
+	assert.True(t, errors.Is(result.Issues[1], utils.ErrMissingChoice))
+	assert.Equal(t, 0, len(result.Alerts()))
+
+	// every broken business rule is reported with the alerts
+	file.XmlData.ReturnHeader.SoftwareId = softwareId
+	file.XmlData.ReturnData.IRS990.PrincipalOfficerNm, file.XmlData.ReturnData.IRS990.PrincipalOfcrBusinessName = officer, officerBusiness
+	file.Attachments = []Attachment{{Name: "explanation.txt", Data: []byte("text")}}
+	result = file.ValidationResult()
+	assert.Equal(t, 2, len(result.Rejects()))
+	assert.Equal(t, utils.SeverityReject, result.Issues[0].Severity)
+	assert.True(t, errors.Is(result.Issues[0], ErrFutureReturnTs))
+	assert.True(t, errors.Is(result.Issues[1], ErrInvalidAttachmentName))
+	assert.Equal(t, 1, len(result.Alerts()))
+
+	// files without validation result report their validation error as a reject
+	assert.Equal(t, result, utils.FileValidationResult(file))
+	result = utils.FileValidationResult(plainReturnFile{file})
+	assert.Equal(t, 1, len(result.Issues))
+	assert.Equal(t, utils.SeverityReject, result.Issues[0].Severity)
+}
+
+// plainReturnFile is a return file without ValidationResult
+type plainReturnFile struct {
+	file *Irs990File
+}
+
+func (r plainReturnFile) Validate() error          { return r.file.Validate() }
+func (r plainReturnFile) ZipData() ([]byte, error) { return r.file.ZipData() }
+func (r plainReturnFile) Version() string          { return r.file.Version() }
+
+func TestValidationHookTest(t *testing.T) {
+	defer utils.ResetValidators()
+
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	returnData := &Return{}
+	err = xml.Unmarshal(InputXML, returnData)
+	assert.Equal(t, nil, err)
+
+	errNotApproved := errors.New("officer title isn't approved")
+	utils.RegisterTypeValidator(PersonTitleType(""), func(value interface{}) error {
+		for _, title := range []string{"CFO", "CEO", "PRESIDENT"} {
+			if string(value.(PersonTitleType)) == title {
+				return nil
+			}
+		}
+		return errNotApproved
+	})
+	errNotHttps := errors.New("website must be https")
+	utils.RegisterPathValidator("IRS990/WebsiteAddressTxt", func(value interface{}) error {
+		if !strings.HasPrefix(strings.ToLower(value.(string)), "https://") {
+			return errNotHttps
+		}
+		return nil
+	})
+
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, errNotHttps))
+	assert.Contains(t, err.Error(), "/Return/ReturnData/IRS990/WebsiteAddressTxt")
+	returnData.ReturnData.IRS990.WebsiteAddressTxt = "https://voiceofsandiego.org"
+	assert.Equal(t, nil, returnData.Validate())
+
+	returnData.ReturnHeader.BusinessOfficerGrp.PersonTitleTxt = "TREASURER"
+	err = returnData.Validate()
+	assert.True(t, errors.Is(err, errNotApproved))
+	assert.Contains(t, err.Error(), "/Return/ReturnHeader/BusinessOfficerGrp/PersonTitleTxt")
+
+	utils.ResetValidators()
+	utils.RegisterPathValidator("/IRSSubmissionManifest/TIN", func(value interface{}) error {
+		return errNotApproved
+	})
+	assert.Equal(t, nil, returnData.Validate())
+	manifest := IRSSubmissionManifest{SubmissionId: "00000020201230000001", EFIN: "000000", GovernmentCd: "IRS", FederalSubmissionTypeCd: "990", TIN: "201585919"}
+	assert.True(t, errors.Is(manifest.Validate(), errNotApproved))
+
+	// validators run without the lock of the registry, a validator may register validators
+	utils.ResetValidators()
+	registered := false
+	utils.RegisterPathValidator("/IRSSubmissionManifest/TIN", func(value interface{}) error {
+		if !registered {
+			registered = true
+			utils.RegisterPathValidator("/IRSSubmissionManifest/EFIN", func(value interface{}) error {
+				return errNotApproved
+			})
+		}
+		return nil
+	})
+	assert.Equal(t, nil, manifest.Validate())
+	assert.True(t, errors.Is(manifest.Validate(), errNotApproved))
+}
+
+func TestSignatureTest(t *testing.T) {
+	InputXML, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	returnData := &Return{}
+	err = xml.Unmarshal(InputXML, returnData)
+	assert.Equal(t, nil, err)
+	header := &returnData.ReturnHeader
+
+	readiness := returnData.SignatureReadiness()
+	assert.False(t, readiness.Ready)
+	assert.True(t, readiness.PaidPreparer)
+	assert.Equal(t, []error{ErrMissingSignature}, readiness.Problems)
+
+	// self-select PIN
+	pin, taxpayer, ero := PINType("12345"), PINEnteredByCd("Taxpayer"), PINEnteredByCd("ERO")
+	option := SignatureOptionCd("PIN Number")
+	header.SignatureOptionCd = &option
+	assert.Equal(t, SelfSelectPINMethod, header.SignatureMethod())
+	assert.True(t, errors.Is(returnData.Validate(), ErrMissingOfficerPIN))
+	header.BusinessOfficerGrp.TaxpayerPIN = &pin
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrMissingPINEnteredBy))
+	header.PINEnteredByCd = &taxpayer
+	assert.Equal(t, nil, returnData.Validate())
+	readiness = returnData.SignatureReadiness()
+	assert.True(t, readiness.Ready)
+	assert.Equal(t, SelfSelectPINMethod, readiness.Method)
+
+	// practitioner PIN
+	header.PINEnteredByCd = &ero
+	assert.Equal(t, PractitionerPINMethod, header.SignatureMethod())
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrMissingPractitionerPIN))
+	header.OriginatorGrp.PractitionerPINGrp = &PractitionerPINGrp{EFIN: "000001", PIN: "54321"}
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrMismatchedPractitionerEFIN))
+	header.OriginatorGrp.PractitionerPINGrp.EFIN = header.OriginatorGrp.EFIN
+	assert.Equal(t, nil, header.ValidateSignature())
+	assert.True(t, returnData.SignatureReadiness().Ready)
+
+	// Form 8453 can't have a PIN
+	option = "Binary Attachment 8453 Signature Document"
+	assert.Equal(t, Form8453Method, header.SignatureMethod())
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrConflictingSignature))
+	header.OriginatorGrp.PractitionerPINGrp, header.BusinessOfficerGrp.TaxpayerPIN, header.PINEnteredByCd = nil, nil, nil
+	assert.Equal(t, nil, header.ValidateSignature())
+
+	// paid preparer
+	header.PreparerPersonGrp.PTIN = ""
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrMissingPreparerPTIN))
+	header.PreparerPersonGrp.PTIN = "P00735101"
+	header.PreparerFirmGrp.PreparerFirmEIN = ""
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrMissingPreparerFirmEIN))
+	header.PreparerFirmGrp.PreparerFirmEIN = "330885895"
+	header.PreparerPersonGrp = nil
+	assert.True(t, errors.Is(header.ValidateSignature(), ErrMissingPreparer))
+	header.PreparerFirmGrp = nil
+	readiness = returnData.SignatureReadiness()
+	assert.True(t, readiness.Ready)
+	assert.False(t, readiness.PaidPreparer)
+}
+
+func TestDuplicateTest(t *testing.T) {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &Irs990File{Manifest: &IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+
+	store := NewMemorySubmissionStore()
+	assert.Equal(t, nil, CheckDuplicates(file, store))
+
+	// an amended return needs an original
+	file.XmlData.ReturnData.IRS990.AmendedReturnInd = "X"
+	assert.True(t, errors.Is(CheckDuplicates(file, store), ErrMissingOriginal))
+	file.XmlData.ReturnData.IRS990.AmendedReturnInd = ""
+
+	original := NewSubmissionRecord(file)
+	assert.False(t, original.Amended)
+	store.Add(original)
+	assert.True(t, errors.Is(CheckDuplicates(file, store), ErrDuplicateSubmissionId))
+
+	// a second original of the tax period
+	file.Manifest.SubmissionId = "00000020201350000001"
+	assert.True(t, errors.Is(CheckDuplicates(file, store), ErrDuplicateOriginal))
+
+	file.XmlData.ReturnData.IRS990.AmendedReturnInd = "X"
+	assert.Equal(t, nil, CheckDuplicates(file, store))
+	file.XmlData.ReturnData.IRS990.AmendedReturnInd = ""
+
+	// rejected originals don't count
+	rejected := NewMemorySubmissionStore()
+	original.Rejected = true
+	rejected.Add(original)
+	assert.Equal(t, nil, CheckDuplicates(file, rejected))
+
+	// other tax periods don't count
+	other := NewMemorySubmissionStore()
+	original.Rejected = false
+	original.TaxPeriodEndDt = DateType(time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC))
+	other.Add(original)
+	assert.Equal(t, nil, CheckDuplicates(file, other))
+}
+
+func TestSubmissionIdTest(t *testing.T) {
+	date := time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewSubmissionIdGenerator("12345", NewMemorySequenceStore())
+	assert.NotNil(t, err)
+	_, err = NewSubmissionIdGenerator("000000", nil)
+	assert.True(t, errors.Is(err, ErrMissingSequenceStore))
+
+	generator, err := NewSubmissionIdGenerator("000000", NewMemorySequenceStore())
+	assert.Equal(t, nil, err)
+	id, err := generator.Next(date)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, SubmissionIdType("00000020200340000000"), id)
+	assert.Equal(t, nil, id.Validate())
+	id, err = generator.Next(date)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, SubmissionIdType("00000020200340000001"), id)
+
+	// concurrent callers get distinct ids
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ids := map[SubmissionIdType]bool{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := generator.Next(date)
+			assert.Equal(t, nil, err)
+			mu.Lock()
+			ids[id] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, len(ids))
+
+	store := &MemorySequenceStore{next: map[string]uint64{"0000002020034": maxSubmissionSequence}}
+	generator, err = NewSubmissionIdGenerator("000000", store)
+	assert.Equal(t, nil, err)
+	id, err = generator.Next(date)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, SubmissionIdType("0000002020034zzzzzzz"), id)
+	_, err = generator.Next(date)
+	assert.True(t, errors.Is(err, ErrExhaustedSequence))
+
+	// a file store continues after a restart
+	path := filepath.Join(t.TempDir(), "sequence.json")
+	generator, err = NewSubmissionIdGenerator("000000", NewFileSequenceStore(path))
+	assert.Equal(t, nil, err)
+	for i := 0; i < 3; i++ {
+		_, err = generator.Next(date)
+		assert.Equal(t, nil, err)
+	}
+	generator, err = NewSubmissionIdGenerator("000000", NewFileSequenceStore(path))
+	assert.Equal(t, nil, err)
+	id, err = generator.Next(date)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, SubmissionIdType("00000020200340000003"), id)
+	id, err = generator.Next(date.AddDate(0, 0, 1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, SubmissionIdType("00000020200350000000"), id)
+
+	// stores sharing the file, as separate processes do, get distinct ids
+	ids = map[SubmissionIdType]bool{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shared, err := NewSubmissionIdGenerator("000000", NewFileSequenceStore(path))
+			assert.Equal(t, nil, err)
+			id, err := shared.Next(date)
+			assert.Equal(t, nil, err)
+			mu.Lock()
+			ids[id] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 20, len(ids))
+
+	assert.Equal(t, nil, os.WriteFile(path, []byte("broken"), 0600))
+	_, err = generator.Next(date)
+	assert.NotNil(t, err)
+}
+
+func TestAckProcessorTest(t *testing.T) {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+	ackBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_acknowledgement_list.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &Irs990File{Manifest: &IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+	file.Manifest.SubmissionId = "00000020193037000002"
+
+	var list AcknowledgementList
+	assert.Equal(t, nil, xml.Unmarshal(ackBuf, &list))
+
+	path, value, err := file.XmlData.FieldPath("/efile:Return[1]/efile:ReturnData[1]/efile:IRS990[1]/efile:Form990PartVIISectionAGrp[2]/efile:PersonNm[1]")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ReturnData.IRS990.Form990PartVIISectionAGrp[1].PersonNm", path)
+	assert.Equal(t, "ROBERT PAGE", value)
+
+	path, value, err = file.XmlData.FieldPath("/Return/@returnVersion")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Version", path)
+	assert.Equal(t, file.XmlData.Version, value)
+
+	// elements missing from the return resolve without value
+	path, value, err = file.XmlData.FieldPath("/Return/ReturnData/IRS990ScheduleA/ChurchInd")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ReturnData.IRS990ScheduleA.ChurchInd", path)
+	assert.Equal(t, "", value)
+	_, _, err = file.XmlData.FieldPath("/Return/ReturnData/Unknown")
+	assert.True(t, errors.Is(err, ErrUnknownXpath))
+	_, _, err = file.XmlData.FieldPath("/Return/ReturnData[x]")
+	assert.True(t, errors.Is(err, ErrUnknownXpath))
+
+	processor := NewAckProcessor()
+	assert.True(t, errors.Is(processor.Add(&Irs990File{}), ErrMissingManifest))
+	assert.Equal(t, nil, processor.Add(file))
+
+	reports := processor.Process(list.Acknowledgement...)
+	assert.Equal(t, 2, len(reports))
+	assert.True(t, reports[0].Accepted())
+	assert.Nil(t, reports[0].File)
+
+	rejected := reports[1]
+	assert.False(t, rejected.Accepted())
+	assert.Equal(t, file, rejected.File)
+	assert.Equal(t, 1, len(rejected.Errors))
+	assert.Equal(t, "ReturnHeader.Filer.EIN", rejected.Errors[0].FieldPath)
+	assert.Equal(t, "201585919", rejected.Errors[0].FieldValue)
+	assert.Equal(t, utils.SeverityRejectAndStop, rejected.Errors[0].Severity)
+	assert.Equal(t, 1, len(rejected.Alerts))
+	assert.Equal(t, "ReturnData.IRS990.WebsiteAddressTxt", rejected.Alerts[0].FieldPath)
+	assert.Equal(t, "VOICEOFSANDIEGO.ORG", rejected.Alerts[0].FieldValue)
+	assert.True(t, strings.Contains(rejected.String(), "ReturnHeader.Filer.EIN (201585919): Reject and Stop R0000-902-01"))
+}
+
+func TestAttachmentTest(t *testing.T) {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &Irs990File{Manifest: &IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+
+	pdf := []byte("%PDF-1.4\n%%EOF\n")
+	binary := BinaryAttachment{Desc: "GENERAL EXPLANATION ATTACHMENT", DocumentId: "RetDoc1038000002"}
+
+	err = file.AddAttachment(Attachment{Name: "general explanation.pdf", Data: pdf}, binary)
+	assert.True(t, errors.Is(err, ErrInvalidAttachmentName))
+	err = file.AddAttachment(Attachment{Name: "explanation.PDF", Data: pdf}, binary)
+	assert.True(t, errors.Is(err, ErrInvalidAttachmentName))
+	err = file.AddAttachment(Attachment{Name: strings.Repeat("a", 61) + ".pdf", Data: pdf}, binary)
+	assert.True(t, errors.Is(err, ErrInvalidAttachmentName))
+	err = file.AddAttachment(Attachment{Name: "explanation.pdf", Data: []byte("plain text")}, binary)
+	assert.True(t, errors.Is(err, ErrNotPDFAttachment))
+
+	assert.Equal(t, nil, file.AddAttachment(Attachment{Name: "explanation.pdf", Data: pdf}, binary))
+	assert.Equal(t, 1, file.XmlData.ReturnHeader.BinaryAttachmentCnt)
+	assert.Equal(t, 7, file.XmlData.ReturnData.DocumentCnt)
+	assert.Equal(t, "attachment/explanation.pdf", file.XmlData.ReturnData.BinaryAttachment[0].AttachmentLocationTxt)
+	assert.Equal(t, DocumentTypeCd("PDF"), file.XmlData.ReturnData.BinaryAttachment[0].DocumentTypeCd)
+	err = file.AddAttachment(Attachment{Name: "explanation.pdf", Data: pdf}, binary)
+	assert.True(t, errors.Is(err, ErrDuplicatedAttachment))
+
+	buf, err := file.ZipData()
+	assert.Equal(t, nil, err)
+	reader, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(reader.File))
+	assert.Equal(t, "attachment/explanation.pdf", reader.File[2].Name)
+
+	// a referenced file is missing
+	attachments := file.Attachments
+	file.Attachments = nil
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrUnresolvedAttachmentLocation))
+
+	// a file isn't referenced
+	file.Attachments = append(attachments, Attachment{Name: "other.pdf", Data: pdf})
+	_, err = file.ZipData()
+	assert.True(t, errors.Is(err, ErrUnreferencedAttachment))
+
+	file.RemoveAttachment("other.pdf")
+	file.RemoveAttachment("explanation.pdf")
+	assert.Equal(t, 0, len(file.Attachments))
+	assert.Equal(t, 0, file.XmlData.ReturnHeader.BinaryAttachmentCnt)
+	assert.Equal(t, 6, file.XmlData.ReturnData.DocumentCnt)
+	_, err = file.ZipData()
+	assert.Equal(t, nil, err)
+}
+
+func TestTypeFormatsTest(t *testing.T) {
+	// the formats describe the patterns and values checked by the Validate methods of types.go
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "types.go", nil, 0)
+	assert.Equal(t, nil, err)
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "Validate" {
+			continue
+		}
+		typeName := fn.Recv.List[0].Type.(*ast.Ident).Name
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "MustCompile" {
+					pattern, err := strconv.Unquote(node.Args[0].(*ast.BasicLit).Value)
+					assert.Equal(t, nil, err)
+					format, ok := ExpectedFormat(typeName)
+					assert.True(t, ok, typeName)
+					assert.Equal(t, pattern, format.Pattern, typeName)
+				}
+			case *ast.CompositeLit:
+				var values []string
+				for _, elt := range node.Elts {
+					if lit, ok := elt.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						value, err := strconv.Unquote(lit.Value)
+						assert.Equal(t, nil, err)
+						values = append(values, strings.TrimSpace(value))
+					}
+				}
+				if len(values) > 0 {
+					format, ok := ExpectedFormat(typeName)
+					assert.True(t, ok, typeName)
+					assert.Equal(t, values, format.Values, typeName)
+				}
+			}
+			return true
+		})
+	}
+
+	// the identifier and bank account formats are the patterns of their validators
+	for typeName, reg := range map[string]*regexp.Regexp{
+		"EINType": einPattern, "SSNType": ssnPattern, "PTINType": ptinPattern, "EFINType": efinPattern,
+		"ETINType": etinPattern, "RoutingTransitNumberType": routingNumberPattern, "BankAccountNumberType": bankAccountPattern,
+	} {
+		format, ok := ExpectedFormat(typeName)
+		assert.True(t, ok, typeName)
+		assert.Equal(t, reg.String(), "^"+format.Pattern+"$", typeName)
+	}
+	format, _ := ExpectedFormat("RoutingTransitNumberType")
+	assert.Equal(t, []string{RuleRoutingCheckDigit}, format.Rules)
+	format, _ = ExpectedFormat("BankAccountNumberType")
+	assert.Equal(t, bankAccountMaxLength, format.MaxLength)
+
+	// lengths and single values are generated too, unchecked types have no format
+	format, _ = ExpectedFormat("TempIdType")
+	assert.Equal(t, 9, format.MaxLength)
+	format, _ = ExpectedFormat("AllStatesCd")
+	assert.Equal(t, []string{"All States"}, format.Values)
+	_, ok := ExpectedFormat("TextType")
+	assert.False(t, ok)
+}
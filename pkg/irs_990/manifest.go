@@ -6,10 +6,17 @@ package irs_990
 
 import (
 	"encoding/xml"
+	"errors"
+	"fmt"
 
 	"github.com/moov-io/1120x/pkg/utils"
 )
 
+var (
+	// ErrMismatchedManifest is given when the submission manifest and the return describe different submissions
+	ErrMismatchedManifest = errors.New("manifest doesn't match the return")
+)
+
 type IRSSubmissionManifest struct {
 	SubmissionId            SubmissionIdType        `xml:"SubmissionId"`
 	EFIN                    EFINType                `xml:"EFIN"`
@@ -23,7 +30,10 @@ type IRSSubmissionManifest struct {
 }
 
 func (r IRSSubmissionManifest) Validate() error {
-	return utils.Validate(&r)
+	if err := utils.Validate(&r); err != nil {
+		return err
+	}
+	return utils.ValidateHooks(&r, "IRSSubmissionManifest")
 }
 
 func (r *IRSSubmissionManifest) Init() error {
@@ -31,6 +41,21 @@ func (r *IRSSubmissionManifest) Init() error {
 	return nil
 }
 
+// ValidateReturn checks that the manifest describes the return of the header.
+// The EIN, the EFIN, the submission type and the tax period must agree with the return.
+func (r IRSSubmissionManifest) ValidateReturn(header ReturnHeaderType) error {
+	if r.TIN != header.Filer.EIN {
+		return fmt.Errorf("%w: TIN is %s, filer EIN is %s", ErrMismatchedManifest, r.TIN, header.Filer.EIN)
+	}
+	if r.EFIN != header.OriginatorGrp.EFIN {
+		return fmt.Errorf("%w: EFIN is %s, originator EFIN is %s", ErrMismatchedManifest, r.EFIN, header.OriginatorGrp.EFIN)
+	}
+	if string(r.FederalSubmissionTypeCd) != string(header.ReturnTypeCd) {
+		return fmt.Errorf("%w: FederalSubmissionTypeCd is %s, ReturnTypeCd is %s", ErrMismatchedManifest, r.FederalSubmissionTypeCd, header.ReturnTypeCd)
+	}
+	return r.ValidateDates(header)
+}
+
 func (r *IRSSubmissionManifest) XmlData() ([]byte, error) {
 	return xml.Marshal(r)
 }
@@ -50,20 +75,23 @@ type StateSubmissionManifest struct {
 	GovernmentCd           GovernmentCodeType      `xml:"GovernmentCd"`
 	StateSubmissionTyp     StateSubmissionTyp      `xml:"StateSubmissionTyp"`
 	SubmissionCategoryCd   SubmissionCategoryType  `xml:"SubmissionCategoryCd"`
-	FederalEIN             FederalEIN              `xml:"FederalEIN"`
-	BusinessNameControlTxt BusinessNameControlType `xml:"BusinessNameControlTxt"`
-	PrimarySSN             SSNType                 `xml:"PrimarySSN"`
-	PrimaryNameControlTxt  PersonNameControlType   `xml:"PrimaryNameControlTxt"`
-	SpouseSSN              SSNType                 `xml:"SpouseSSN"`
-	SpouseNameControlTxt   PersonNameControlType   `xml:"SpouseNameControlTxt"`
-	TempId                 TempIdType              `xml:"TempId"`
+	FederalEIN             FederalEIN              `xml:"FederalEIN,omitempty" json:",omitempty" choice:"FederalEIN"`
+	BusinessNameControlTxt BusinessNameControlType `xml:"BusinessNameControlTxt,omitempty" json:",omitempty" choice:"FederalEIN"`
+	PrimarySSN             SSNType                 `xml:"PrimarySSN,omitempty" json:",omitempty" choice:"FederalEIN"`
+	PrimaryNameControlTxt  PersonNameControlType   `xml:"PrimaryNameControlTxt,omitempty" json:",omitempty" choice:"FederalEIN"`
+	SpouseSSN              SSNType                 `xml:"SpouseSSN,omitempty" json:",omitempty" choice:"FederalEIN"`
+	SpouseNameControlTxt   PersonNameControlType   `xml:"SpouseNameControlTxt,omitempty" json:",omitempty" choice:"FederalEIN"`
+	TempId                 TempIdType              `xml:"TempId,omitempty" json:",omitempty" choice:"FederalEIN"`
 	IRSSubmissionId        *SubmissionIdType       `xml:"IRSSubmissionId,omitempty" json:",omitempty"`
 	StateSchemaVersionNum  string                  `xml:"StateSchemaVersionNum,omitempty" json:",omitempty"`
 	Xmlns                  string                  `xml:"xmlns,attr,omitempty" json:",omitempty"`
 }
 
 func (r StateSubmissionManifest) Validate() error {
-	return utils.Validate(&r)
+	if err := utils.Validate(&r); err != nil {
+		return err
+	}
+	return utils.ValidateHooks(&r, "StateSubmissionManifest")
 }
 
 func (r *StateSubmissionManifest) Init() error {
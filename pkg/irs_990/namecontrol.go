@@ -0,0 +1,122 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMismatchedNameControl is given when a name control doesn't match the name control derived from the name
+	ErrMismatchedNameControl = errors.New("name control doesn't match the name")
+)
+
+var nameControlLength = 4
+
+// BusinessNameControl derives the name control of a business from the first name line.
+//
+// The name control is the first four significant characters of the name:
+//   - letters are capitalized, blanks and punctuation are dropped
+//   - numbers, ampersands (&) and hyphens (-) are kept
+//   - a leading "The" is dropped when more than one word follows it
+func BusinessNameControl(name BusinessNameLine1Type) BusinessNameControlType {
+	words := strings.Fields(strings.ToUpper(string(name)))
+	if len(words) > 2 && words[0] == "THE" {
+		words = words[1:]
+	}
+
+	var control strings.Builder
+	for _, c := range strings.Join(words, "") {
+		if control.Len() == nameControlLength {
+			break
+		}
+		if (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '&' || c == '-' {
+			control.WriteRune(c)
+		}
+	}
+
+	return BusinessNameControlType(control.String())
+}
+
+// PersonNameControl derives the name control of an individual from the last name.
+//
+// The name control is the first four letters of the last name:
+//   - letters are capitalized, blanks and apostrophes are dropped ("O'Neil", "De La Rosa")
+//   - hyphens (-) are kept, but never as the first character
+func PersonNameControl(lastName PersonLastNameType) PersonNameControlType {
+	var control strings.Builder
+	for _, c := range strings.ToUpper(string(lastName)) {
+		if control.Len() == nameControlLength {
+			break
+		}
+		if (c >= 'A' && c <= 'Z') || (c == '-' && control.Len() > 0) {
+			control.WriteRune(c)
+		}
+	}
+
+	return PersonNameControlType(control.String())
+}
+
+func businessNameControlWarning(control BusinessNameControlType, name BusinessNameLine1Type) error {
+	expected := BusinessNameControl(name)
+	if strings.TrimSpace(string(control)) == string(expected) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is expected for %s, not %s", ErrMismatchedNameControl, expected, name, control)
+}
+
+func personNameControlWarning(control PersonNameControlType, lastName PersonLastNameType) error {
+	expected := PersonNameControl(lastName)
+	if strings.TrimSpace(string(control)) == string(expected) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is expected for %s, not %s", ErrMismatchedNameControl, expected, lastName, control)
+}
+
+// NameControlWarnings reports a business name control that doesn't match the business name.
+// The mismatch isn't a validation error, the IRS keeps the name control on file.
+func (r Filer) NameControlWarnings() []error {
+	var warnings []error
+	if err := businessNameControlWarning(r.BusinessNameControlTxt, r.BusinessName.BusinessNameLine1Txt); err != nil {
+		warnings = append(warnings, err)
+	}
+	return warnings
+}
+
+// NameControlNames are the taxpayer names that the name controls of a state submission manifest are derived from
+type NameControlNames struct {
+	BusinessName    BusinessNameLine1Type
+	PrimaryLastName PersonLastNameType
+	SpouseLastName  PersonLastNameType
+}
+
+// NameControlWarnings reports name controls of the manifest that don't match the taxpayer names.
+// Only supplied name controls that have a name are checked.
+func (r StateSubmissionManifest) NameControlWarnings(names NameControlNames) []error {
+	var warnings []error
+	if len(r.BusinessNameControlTxt) > 0 && len(names.BusinessName) > 0 {
+		if err := businessNameControlWarning(r.BusinessNameControlTxt, names.BusinessName); err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+	if len(r.PrimaryNameControlTxt) > 0 && len(names.PrimaryLastName) > 0 {
+		if err := personNameControlWarning(r.PrimaryNameControlTxt, names.PrimaryLastName); err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+	if len(r.SpouseNameControlTxt) > 0 && len(names.SpouseLastName) > 0 {
+		if err := personNameControlWarning(r.SpouseNameControlTxt, names.SpouseLastName); err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+	return warnings
+}
+
+// Warnings reports problems of the return that don't fail validation but commonly cause rejects
+func (r *Return) Warnings() []error {
+	return r.ReturnHeader.Filer.NameControlWarnings()
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidTaxPeriod is given when the tax period ends before it begins or is longer than a tax year
+	ErrInvalidTaxPeriod = errors.New("tax period is invalid")
+	// ErrMismatchedTaxYr is given when the tax year isn't the year the tax period begins
+	ErrMismatchedTaxYr = errors.New("tax year doesn't match the tax period")
+	// ErrFutureReturnTs is given when the return timestamp is in the future
+	ErrFutureReturnTs = errors.New("return timestamp is in the future")
+	// ErrUnacceptedTaxYr is given when the schema version of the return doesn't accept the tax period
+	ErrUnacceptedTaxYr = errors.New("tax year isn't accepted by the return version")
+	// ErrMismatchedManifestPeriod is given when the submission manifest and the return have different tax periods
+	ErrMismatchedManifestPeriod = errors.New("tax period of the manifest doesn't match the return")
+)
+
+// A 53-week tax year (52-53 week year) is the only period longer than 12 months
+var fiftyThreeWeekDays = 53 * 7
+
+// ValidateTaxPeriod checks that the tax period is no longer than 12 months, unless it is a 53-week year
+func ValidateTaxPeriod(begin, end DateType) error {
+	beginDt, endDt := time.Time(begin), time.Time(end)
+	if endDt.Before(beginDt) {
+		return fmt.Errorf("%w: ends %s before it begins %s", ErrInvalidTaxPeriod, dateString(end), dateString(begin))
+	}
+
+	// the last day of a 12 months period
+	lastDay := beginDt.AddDate(1, 0, -1)
+	if endDt.After(lastDay) && daysOfPeriod(beginDt, endDt) != fiftyThreeWeekDays {
+		return fmt.Errorf("%w: %s - %s is longer than 12 months", ErrInvalidTaxPeriod, dateString(begin), dateString(end))
+	}
+
+	return nil
+}
+
+// ValidateTaxYr checks that the tax year is the year the tax period begins
+func ValidateTaxYr(taxYr YearType, begin DateType) error {
+	if time.Time(taxYr).Year() != time.Time(begin).Year() {
+		return fmt.Errorf("%w: tax year is %d, tax period begins %s", ErrMismatchedTaxYr, time.Time(taxYr).Year(), dateString(begin))
+	}
+	return nil
+}
+
+// ValidateDates checks the tax period, the tax year and the return timestamp of the header
+func (r ReturnHeaderType) ValidateDates() error {
+	if err := ValidateTaxPeriod(r.TaxPeriodBeginDt, r.TaxPeriodEndDt); err != nil {
+		return err
+	}
+	if err := ValidateTaxYr(r.TaxYr, r.TaxPeriodBeginDt); err != nil {
+		return err
+	}
+	if returnTs := time.Time(r.ReturnTs); returnTs.After(time.Now()) {
+		return fmt.Errorf("%w: %s", ErrFutureReturnTs, returnTs.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// ValidateDates checks the dates of the header and that the return version accepts the tax period,
+// a version that isn't registered is an error
+func (r *Return) ValidateDates() error {
+	if err := r.ReturnHeader.ValidateDates(); err != nil {
+		return err
+	}
+	version, err := LookupVersion(r.Version)
+	if err != nil {
+		return err
+	}
+	return version.ValidateTaxPeriod(r.ReturnHeader.TaxPeriodBeginDt, r.ReturnHeader.TaxPeriodEndDt)
+}
+
+// ValidateDates checks the tax period and the tax year of the manifest, when given, against the return header
+func (r IRSSubmissionManifest) ValidateDates(header ReturnHeaderType) error {
+	if r.TaxYr != nil && time.Time(*r.TaxYr).Year() != time.Time(header.TaxYr).Year() {
+		return fmt.Errorf("%w: tax year is %d, return has %d", ErrMismatchedManifestPeriod, time.Time(*r.TaxYr).Year(), time.Time(header.TaxYr).Year())
+	}
+	if r.TaxPeriodBeginDt != nil && !sameDate(*r.TaxPeriodBeginDt, header.TaxPeriodBeginDt) {
+		return fmt.Errorf("%w: tax period begins %s, return begins %s", ErrMismatchedManifestPeriod, dateString(*r.TaxPeriodBeginDt), dateString(header.TaxPeriodBeginDt))
+	}
+	if r.TaxPeriodEndDt != nil && !sameDate(*r.TaxPeriodEndDt, header.TaxPeriodEndDt) {
+		return fmt.Errorf("%w: tax period ends %s, return ends %s", ErrMismatchedManifestPeriod, dateString(*r.TaxPeriodEndDt), dateString(header.TaxPeriodEndDt))
+	}
+	return nil
+}
+
+// isShortPeriod reports whether the tax period is shorter than 12 months
+func isShortPeriod(begin, end DateType) bool {
+	return time.Time(end).Before(time.Time(begin).AddDate(1, 0, -1))
+}
+
+func daysOfPeriod(begin, end time.Time) int {
+	return int(end.Sub(begin).Round(24*time.Hour).Hours()/24) + 1
+}
+
+func sameDate(a, b DateType) bool {
+	return dateString(a) == dateString(b)
+}
+
+func dateString(date DateType) string {
+	return time.Time(date).Format("2006-01-02")
+}
@@ -8,8 +8,6 @@ import (
 	"encoding/xml"
 	"reflect"
 	"regexp"
-	"strconv"
-	"strings"
 
 	"github.com/moov-io/1120x/pkg/utils"
 )
@@ -17,8 +15,8 @@ import (
 type Return struct {
 	Text           string `xml:",chardata"`
 	Xmlns          string `xml:"xmlns,attr,omitempty" json:",omitempty"`
-	Xsi            string `xml:"xsi,attr"`
-	SchemaLocation string `xml:"schemaLocation,attr"`
+	Xsi            string `xml:"xsi,attr,omitempty" json:",omitempty"`
+	SchemaLocation string `xml:"schemaLocation,attr,omitempty" json:",omitempty"`
 	Version        string `xml:"returnVersion,attr"`
 
 	ReturnHeader ReturnHeaderType `xml:"ReturnHeader"`
@@ -146,17 +144,22 @@ func (r *Return) InspectData() *utils.ReturnInspectInfo {
 	return &utils.ReturnInspectInfo{Header: r.ReturnHeader, Data: returnData}
 }
 
-// ReturnYear returns year of return year
+// ReturnYear returns tax year of the return version, 0 when the version isn't registered
 func (r *Return) ReturnYear() int {
-	splits := strings.Split(r.Version, "v")
-	if len(splits[0]) == 0 {
+	version, err := LookupVersion(r.Version)
+	if err != nil {
 		return 0
 	}
-	year, err := strconv.Atoi(splits[0])
+	return version.TaxYr
+}
+
+// StylesheetDir returns stylesheet directory of the return version
+func (r *Return) StylesheetDir() string {
+	version, err := LookupVersion(r.Version)
 	if err != nil {
-		return 0
+		return ""
 	}
-	return year
+	return version.StylesheetDir
 }
 
 // ReturnYear returns year of return version
@@ -183,8 +186,54 @@ func (r *Return) String() string {
 	return re.ReplaceAllString(string(buf), "")
 }
 
+// Validate checks the return as a production return, test identifiers aren't allowed
 func (r Return) Validate() error {
-	return utils.Validate(&r)
+	return r.validate(false)
+}
+
+// validate checks the return, a test return may use test identifiers
+func (r Return) validate(test bool) error {
+	if err := utils.Validate(&r); err != nil {
+		return err
+	}
+	if err := r.ValidateVersion(); err != nil {
+		return err
+	}
+	if !test {
+		if err := utils.ValidateProductionIdentifiers(&r); err != nil {
+			return err
+		}
+	}
+	if errs := r.RuleErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// RuleErrors checks the business rules of a return with valid elements, an error per broken rule
+func (r *Return) RuleErrors() []error {
+	var errs []error
+	for _, err := range []error{
+		utils.ValidateHooks(r, "Return"),
+		r.ValidateCodeLists(),
+		r.ValidateConsistency(),
+		r.ValidateDates(),
+		r.ReturnHeader.ValidateSignature(),
+	} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ValidateVersion checks that the return version is registered and that the return has only elements of the version
+func (r *Return) ValidateVersion() error {
+	version, err := LookupVersion(r.Version)
+	if err != nil {
+		return err
+	}
+	return version.ValidateElements(r)
 }
 
 func (r *Return) Init() error {
@@ -7,22 +7,22 @@ package irs_990
 import "github.com/moov-io/1120x/pkg/utils"
 
 type IRS990ScheduleA struct {
-	ChurchInd                      CheckboxType                     `xml:"ChurchInd"`
-	SchoolInd                      CheckboxType                     `xml:"SchoolInd"`
-	HospitalInd                    CheckboxType                     `xml:"HospitalInd"`
-	MedicalResearchOrganizationInd CheckboxType                     `xml:"MedicalResearchOrganizationInd"`
-	HospitalNameAndAddressGrp      []HospitalNameAndAddressGrpType  `xml:"HospitalNameAndAddressGrp"`
-	CollegeOrganizationInd         CheckboxType                     `xml:"CollegeOrganizationInd"`
-	GovernmentalUnitInd            CheckboxType                     `xml:"GovernmentalUnitInd"`
-	PublicOrganization170Ind       CheckboxType                     `xml:"PublicOrganization170Ind"`
-	CommunityTrustInd              CheckboxType                     `xml:"CommunityTrustInd"`
-	PubliclySupportedOrg509a2Ind   CheckboxType                     `xml:"PubliclySupportedOrg509a2Ind"`
-	TestPublicSafetyInd            CheckboxType                     `xml:"TestPublicSafetyInd"`
-	SupportingOrganization509a3Ind CheckboxType                     `xml:"SupportingOrganization509a3Ind"`
-	SupportingOrgType1Ind          CheckboxType                     `xml:"SupportingOrgType1Ind"`
-	SupportingOrgType2Ind          CheckboxType                     `xml:"SupportingOrgType2Ind"`
-	SupportingOrgType3FuncIntInd   CheckboxType                     `xml:"SupportingOrgType3FuncIntInd"`
-	SupportingOrgType3NonFuncInd   CheckboxType                     `xml:"SupportingOrgType3NonFuncInd"`
+	ChurchInd                      CheckboxType                     `xml:"ChurchInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	SchoolInd                      CheckboxType                     `xml:"SchoolInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	HospitalInd                    CheckboxType                     `xml:"HospitalInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	MedicalResearchOrganizationInd CheckboxType                     `xml:"MedicalResearchOrganizationInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	HospitalNameAndAddressGrp      []HospitalNameAndAddressGrpType  `xml:"HospitalNameAndAddressGrp,omitempty" json:",omitempty" choice:"ChurchInd"`
+	CollegeOrganizationInd         CheckboxType                     `xml:"CollegeOrganizationInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	GovernmentalUnitInd            CheckboxType                     `xml:"GovernmentalUnitInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	PublicOrganization170Ind       CheckboxType                     `xml:"PublicOrganization170Ind,omitempty" json:",omitempty" choice:"ChurchInd"`
+	CommunityTrustInd              CheckboxType                     `xml:"CommunityTrustInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	PubliclySupportedOrg509a2Ind   CheckboxType                     `xml:"PubliclySupportedOrg509a2Ind,omitempty" json:",omitempty" choice:"ChurchInd"`
+	TestPublicSafetyInd            CheckboxType                     `xml:"TestPublicSafetyInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	SupportingOrganization509a3Ind CheckboxType                     `xml:"SupportingOrganization509a3Ind,omitempty" json:",omitempty" choice:"ChurchInd"`
+	SupportingOrgType1Ind          CheckboxType                     `xml:"SupportingOrgType1Ind,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
+	SupportingOrgType2Ind          CheckboxType                     `xml:"SupportingOrgType2Ind,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
+	SupportingOrgType3FuncIntInd   CheckboxType                     `xml:"SupportingOrgType3FuncIntInd,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
+	SupportingOrgType3NonFuncInd   CheckboxType                     `xml:"SupportingOrgType3NonFuncInd,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
 	IRSWrittenDeterminationInd     CheckboxType                     `xml:"IRSWrittenDeterminationInd,omitempty" json:",omitempty"`
 	SupportedOrganizationsCnt      int                              `xml:"SupportedOrganizationsCnt,omitempty" json:",omitempty"`
 	SupportedOrgInformationGrp     []SupportedOrgInformationGrpType `xml:"SupportedOrgInformationGrp,omitempty" json:",omitempty"`
@@ -97,22 +97,22 @@ func (r IRS990ScheduleA) Validate() error {
 
 // Content model for Form 990 Schedule A
 type IRS990ScheduleAType struct {
-	ChurchInd                      CheckboxType                     `xml:"ChurchInd"`
-	SchoolInd                      CheckboxType                     `xml:"SchoolInd"`
-	HospitalInd                    CheckboxType                     `xml:"HospitalInd"`
-	MedicalResearchOrganizationInd CheckboxType                     `xml:"MedicalResearchOrganizationInd"`
-	HospitalNameAndAddressGrp      []HospitalNameAndAddressGrpType  `xml:"HospitalNameAndAddressGrp"`
-	CollegeOrganizationInd         CheckboxType                     `xml:"CollegeOrganizationInd"`
-	GovernmentalUnitInd            CheckboxType                     `xml:"GovernmentalUnitInd"`
-	PublicOrganization170Ind       CheckboxType                     `xml:"PublicOrganization170Ind"`
-	CommunityTrustInd              CheckboxType                     `xml:"CommunityTrustInd"`
-	PubliclySupportedOrg509a2Ind   CheckboxType                     `xml:"PubliclySupportedOrg509a2Ind"`
-	TestPublicSafetyInd            CheckboxType                     `xml:"TestPublicSafetyInd"`
-	SupportingOrganization509a3Ind CheckboxType                     `xml:"SupportingOrganization509a3Ind"`
-	SupportingOrgType1Ind          CheckboxType                     `xml:"SupportingOrgType1Ind"`
-	SupportingOrgType2Ind          CheckboxType                     `xml:"SupportingOrgType2Ind"`
-	SupportingOrgType3FuncIntInd   CheckboxType                     `xml:"SupportingOrgType3FuncIntInd"`
-	SupportingOrgType3NonFuncInd   CheckboxType                     `xml:"SupportingOrgType3NonFuncInd"`
+	ChurchInd                      CheckboxType                     `xml:"ChurchInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	SchoolInd                      CheckboxType                     `xml:"SchoolInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	HospitalInd                    CheckboxType                     `xml:"HospitalInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	MedicalResearchOrganizationInd CheckboxType                     `xml:"MedicalResearchOrganizationInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	HospitalNameAndAddressGrp      []HospitalNameAndAddressGrpType  `xml:"HospitalNameAndAddressGrp,omitempty" json:",omitempty" choice:"ChurchInd"`
+	CollegeOrganizationInd         CheckboxType                     `xml:"CollegeOrganizationInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	GovernmentalUnitInd            CheckboxType                     `xml:"GovernmentalUnitInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	PublicOrganization170Ind       CheckboxType                     `xml:"PublicOrganization170Ind,omitempty" json:",omitempty" choice:"ChurchInd"`
+	CommunityTrustInd              CheckboxType                     `xml:"CommunityTrustInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	PubliclySupportedOrg509a2Ind   CheckboxType                     `xml:"PubliclySupportedOrg509a2Ind,omitempty" json:",omitempty" choice:"ChurchInd"`
+	TestPublicSafetyInd            CheckboxType                     `xml:"TestPublicSafetyInd,omitempty" json:",omitempty" choice:"ChurchInd"`
+	SupportingOrganization509a3Ind CheckboxType                     `xml:"SupportingOrganization509a3Ind,omitempty" json:",omitempty" choice:"ChurchInd"`
+	SupportingOrgType1Ind          CheckboxType                     `xml:"SupportingOrgType1Ind,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
+	SupportingOrgType2Ind          CheckboxType                     `xml:"SupportingOrgType2Ind,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
+	SupportingOrgType3FuncIntInd   CheckboxType                     `xml:"SupportingOrgType3FuncIntInd,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
+	SupportingOrgType3NonFuncInd   CheckboxType                     `xml:"SupportingOrgType3NonFuncInd,omitempty" json:",omitempty" choice:"SupportingOrgType1Ind" choiceIf:"SupportingOrganization509a3Ind"`
 	IRSWrittenDeterminationInd     CheckboxType                     `xml:"IRSWrittenDeterminationInd,omitempty" json:",omitempty"`
 	SupportedOrganizationsCnt      int                              `xml:"SupportedOrganizationsCnt,omitempty" json:",omitempty"`
 	SupportedOrgInformationGrp     []SupportedOrgInformationGrpType `xml:"SupportedOrgInformationGrp,omitempty" json:",omitempty"`
@@ -182,12 +182,12 @@ func (r IRS990ScheduleAType) Validate() error {
 }
 
 type IRS990ScheduleB struct {
-	Organization501cInd            *Organization501cInd                 `xml:"Organization501cInd,omitempty" json:",omitempty"`
-	Organization4947a1NotPFInd     CheckboxType                         `xml:"Organization4947a1NotPFInd"`
-	Organization527Ind             CheckboxType                         `xml:"Organization527Ind"`
-	Organization501c3ExemptPFInd   CheckboxType                         `xml:"Organization501c3ExemptPFInd"`
-	Organization4947a1TrtdPFInd    CheckboxType                         `xml:"Organization4947a1TrtdPFInd"`
-	Organization501c3TaxablePFInd  CheckboxType                         `xml:"Organization501c3TaxablePFInd"`
+	Organization501cInd            *Organization501cInd                 `xml:"Organization501cInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization4947a1NotPFInd     CheckboxType                         `xml:"Organization4947a1NotPFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization527Ind             CheckboxType                         `xml:"Organization527Ind,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization501c3ExemptPFInd   CheckboxType                         `xml:"Organization501c3ExemptPFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization4947a1TrtdPFInd    CheckboxType                         `xml:"Organization4947a1TrtdPFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization501c3TaxablePFInd  CheckboxType                         `xml:"Organization501c3TaxablePFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
 	GeneralRuleInd                 CheckboxType                         `xml:"GeneralRuleInd,omitempty" json:",omitempty"`
 	SpclRuleMetOne3rdSuprtTestInd  CheckboxType                         `xml:"SpclRuleMetOne3rdSuprtTestInd,omitempty" json:",omitempty"`
 	TotContriRcvdMore1000Ind       CheckboxType                         `xml:"TotContriRcvdMore1000Ind,omitempty" json:",omitempty"`
@@ -208,12 +208,12 @@ func (r IRS990ScheduleB) Validate() error {
 
 // Content model for Form 990 Schedule B
 type IRS990ScheduleBType struct {
-	Organization501cInd            *Organization501cInd                 `xml:"Organization501cInd,omitempty" json:",omitempty"`
-	Organization4947a1NotPFInd     CheckboxType                         `xml:"Organization4947a1NotPFInd"`
-	Organization527Ind             CheckboxType                         `xml:"Organization527Ind"`
-	Organization501c3ExemptPFInd   CheckboxType                         `xml:"Organization501c3ExemptPFInd"`
-	Organization4947a1TrtdPFInd    CheckboxType                         `xml:"Organization4947a1TrtdPFInd"`
-	Organization501c3TaxablePFInd  CheckboxType                         `xml:"Organization501c3TaxablePFInd"`
+	Organization501cInd            *Organization501cInd                 `xml:"Organization501cInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization4947a1NotPFInd     CheckboxType                         `xml:"Organization4947a1NotPFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization527Ind             CheckboxType                         `xml:"Organization527Ind,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization501c3ExemptPFInd   CheckboxType                         `xml:"Organization501c3ExemptPFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization4947a1TrtdPFInd    CheckboxType                         `xml:"Organization4947a1TrtdPFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
+	Organization501c3TaxablePFInd  CheckboxType                         `xml:"Organization501c3TaxablePFInd,omitempty" json:",omitempty" choice:"Organization501cInd"`
 	GeneralRuleInd                 CheckboxType                         `xml:"GeneralRuleInd,omitempty" json:",omitempty"`
 	SpclRuleMetOne3rdSuprtTestInd  CheckboxType                         `xml:"SpclRuleMetOne3rdSuprtTestInd,omitempty" json:",omitempty"`
 	TotContriRcvdMore1000Ind       CheckboxType                         `xml:"TotContriRcvdMore1000Ind,omitempty" json:",omitempty"`
@@ -705,8 +705,8 @@ type IRS990ScheduleG struct {
 	TotalGrossReceiptsAmt          int                                 `xml:"TotalGrossReceiptsAmt,omitempty" json:",omitempty"`
 	TotalRetainedByContractorsAmt  int                                 `xml:"TotalRetainedByContractorsAmt,omitempty" json:",omitempty"`
 	TotalNetToOrganizationAmt      int                                 `xml:"TotalNetToOrganizationAmt,omitempty" json:",omitempty"`
-	LicensedStatesCd               []StateType                         `xml:"LicensedStatesCd"`
-	AllStatesCd                    AllStatesCd                         `xml:"AllStatesCd"`
+	LicensedStatesCd               []StateType                         `xml:"LicensedStatesCd,omitempty" json:",omitempty" choice:"LicensedStatesCd,optional"`
+	AllStatesCd                    AllStatesCd                         `xml:"AllStatesCd,omitempty" json:",omitempty" choice:"LicensedStatesCd,optional"`
 	FundraisingEventInformationGrp *FundraisingEventInformationGrpType `xml:"FundraisingEventInformationGrp,omitempty" json:",omitempty"`
 	GamingInformationGrp           *GamingInformationGrpType           `xml:"GamingInformationGrp,omitempty" json:",omitempty"`
 	StatesWhereGamingConductedCd   []StateType                         `xml:"StatesWhereGamingConductedCd,omitempty" json:",omitempty"`
@@ -718,19 +718,19 @@ type IRS990ScheduleG struct {
 	MemberOfOtherEntityInd         bool                                `xml:"MemberOfOtherEntityInd,omitempty" json:",omitempty"`
 	GamingOwnFacilityPct           float64                             `xml:"GamingOwnFacilityPct,omitempty" json:",omitempty"`
 	GamingOtherFacilityPct         float64                             `xml:"GamingOtherFacilityPct,omitempty" json:",omitempty"`
-	IndividualWithBooksNm          PersonNameType                      `xml:"IndividualWithBooksNm"`
-	PersonsWithBooksName           *BusinessNameType                   `xml:"PersonsWithBooksName"`
-	PersonsWithBooksUSAddress      *USAddressType                      `xml:"PersonsWithBooksUSAddress"`
-	PersonsWithBooksForeignAddress *ForeignAddressType                 `xml:"PersonsWithBooksForeignAddress"`
+	IndividualWithBooksNm          PersonNameType                      `xml:"IndividualWithBooksNm,omitempty" json:",omitempty" choice:"IndividualWithBooksNm" choiceIf:"GamingInformationGrp"`
+	PersonsWithBooksName           *BusinessNameType                   `xml:"PersonsWithBooksName,omitempty" json:",omitempty" choice:"IndividualWithBooksNm" choiceIf:"GamingInformationGrp"`
+	PersonsWithBooksUSAddress      *USAddressType                      `xml:"PersonsWithBooksUSAddress,omitempty" json:",omitempty" choice:"PersonsWithBooksUSAddress" choiceIf:"GamingInformationGrp"`
+	PersonsWithBooksForeignAddress *ForeignAddressType                 `xml:"PersonsWithBooksForeignAddress,omitempty" json:",omitempty" choice:"PersonsWithBooksUSAddress" choiceIf:"GamingInformationGrp"`
 	CntrctWith3rdPrtyForGameRevInd bool                                `xml:"CntrctWith3rdPrtyForGameRevInd,omitempty" json:",omitempty"`
 	GamingRevenueReceivedByOrgAmt  int                                 `xml:"GamingRevenueReceivedByOrgAmt,omitempty" json:",omitempty"`
 	GamingRevenueRtnBy3PrtyAmt     int                                 `xml:"GamingRevenueRtnBy3PrtyAmt,omitempty" json:",omitempty"`
-	ThirdPartyPersonNm             PersonNameType                      `xml:"ThirdPartyPersonNm"`
-	ThirdPartyBusinessName         *BusinessNameType                   `xml:"ThirdPartyBusinessName"`
-	ThirdPartyUSAddress            *USAddressType                      `xml:"ThirdPartyUSAddress"`
-	ThirdPartyForeignAddress       *ForeignAddressType                 `xml:"ThirdPartyForeignAddress"`
-	GamingManagerPersonNm          PersonNameType                      `xml:"GamingManagerPersonNm"`
-	GamingManagerBusinessName      *BusinessNameType                   `xml:"GamingManagerBusinessName"`
+	ThirdPartyPersonNm             PersonNameType                      `xml:"ThirdPartyPersonNm,omitempty" json:",omitempty" choice:"ThirdPartyPersonNm" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	ThirdPartyBusinessName         *BusinessNameType                   `xml:"ThirdPartyBusinessName,omitempty" json:",omitempty" choice:"ThirdPartyPersonNm" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	ThirdPartyUSAddress            *USAddressType                      `xml:"ThirdPartyUSAddress,omitempty" json:",omitempty" choice:"ThirdPartyUSAddress" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	ThirdPartyForeignAddress       *ForeignAddressType                 `xml:"ThirdPartyForeignAddress,omitempty" json:",omitempty" choice:"ThirdPartyUSAddress" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	GamingManagerPersonNm          PersonNameType                      `xml:"GamingManagerPersonNm,omitempty" json:",omitempty" choice:"GamingManagerPersonNm" choiceIf:"GamingInformationGrp"`
+	GamingManagerBusinessName      *BusinessNameType                   `xml:"GamingManagerBusinessName,omitempty" json:",omitempty" choice:"GamingManagerPersonNm" choiceIf:"GamingInformationGrp"`
 	GamingManagerCompensationAmt   int                                 `xml:"GamingManagerCompensationAmt,omitempty" json:",omitempty"`
 	GamingManagerServicesProvTxt   string                              `xml:"GamingManagerServicesProvTxt,omitempty" json:",omitempty"`
 	GamingManagerIsDirectorOfcrInd CheckboxType                        `xml:"GamingManagerIsDirectorOfcrInd,omitempty" json:",omitempty"`
@@ -763,8 +763,8 @@ type IRS990ScheduleGType struct {
 	TotalGrossReceiptsAmt          int                                 `xml:"TotalGrossReceiptsAmt,omitempty" json:",omitempty"`
 	TotalRetainedByContractorsAmt  int                                 `xml:"TotalRetainedByContractorsAmt,omitempty" json:",omitempty"`
 	TotalNetToOrganizationAmt      int                                 `xml:"TotalNetToOrganizationAmt,omitempty" json:",omitempty"`
-	LicensedStatesCd               []StateType                         `xml:"LicensedStatesCd"`
-	AllStatesCd                    AllStatesCd                         `xml:"AllStatesCd"`
+	LicensedStatesCd               []StateType                         `xml:"LicensedStatesCd,omitempty" json:",omitempty" choice:"LicensedStatesCd,optional"`
+	AllStatesCd                    AllStatesCd                         `xml:"AllStatesCd,omitempty" json:",omitempty" choice:"LicensedStatesCd,optional"`
 	FundraisingEventInformationGrp *FundraisingEventInformationGrpType `xml:"FundraisingEventInformationGrp,omitempty" json:",omitempty"`
 	GamingInformationGrp           *GamingInformationGrpType           `xml:"GamingInformationGrp,omitempty" json:",omitempty"`
 	StatesWhereGamingConductedCd   []StateType                         `xml:"StatesWhereGamingConductedCd,omitempty" json:",omitempty"`
@@ -776,19 +776,19 @@ type IRS990ScheduleGType struct {
 	MemberOfOtherEntityInd         bool                                `xml:"MemberOfOtherEntityInd,omitempty" json:",omitempty"`
 	GamingOwnFacilityPct           float64                             `xml:"GamingOwnFacilityPct,omitempty" json:",omitempty"`
 	GamingOtherFacilityPct         float64                             `xml:"GamingOtherFacilityPct,omitempty" json:",omitempty"`
-	IndividualWithBooksNm          PersonNameType                      `xml:"IndividualWithBooksNm"`
-	PersonsWithBooksName           *BusinessNameType                   `xml:"PersonsWithBooksName"`
-	PersonsWithBooksUSAddress      *USAddressType                      `xml:"PersonsWithBooksUSAddress"`
-	PersonsWithBooksForeignAddress *ForeignAddressType                 `xml:"PersonsWithBooksForeignAddress"`
+	IndividualWithBooksNm          PersonNameType                      `xml:"IndividualWithBooksNm,omitempty" json:",omitempty" choice:"IndividualWithBooksNm" choiceIf:"GamingInformationGrp"`
+	PersonsWithBooksName           *BusinessNameType                   `xml:"PersonsWithBooksName,omitempty" json:",omitempty" choice:"IndividualWithBooksNm" choiceIf:"GamingInformationGrp"`
+	PersonsWithBooksUSAddress      *USAddressType                      `xml:"PersonsWithBooksUSAddress,omitempty" json:",omitempty" choice:"PersonsWithBooksUSAddress" choiceIf:"GamingInformationGrp"`
+	PersonsWithBooksForeignAddress *ForeignAddressType                 `xml:"PersonsWithBooksForeignAddress,omitempty" json:",omitempty" choice:"PersonsWithBooksUSAddress" choiceIf:"GamingInformationGrp"`
 	CntrctWith3rdPrtyForGameRevInd bool                                `xml:"CntrctWith3rdPrtyForGameRevInd,omitempty" json:",omitempty"`
 	GamingRevenueReceivedByOrgAmt  int                                 `xml:"GamingRevenueReceivedByOrgAmt,omitempty" json:",omitempty"`
 	GamingRevenueRtnBy3PrtyAmt     int                                 `xml:"GamingRevenueRtnBy3PrtyAmt,omitempty" json:",omitempty"`
-	ThirdPartyPersonNm             PersonNameType                      `xml:"ThirdPartyPersonNm"`
-	ThirdPartyBusinessName         *BusinessNameType                   `xml:"ThirdPartyBusinessName"`
-	ThirdPartyUSAddress            *USAddressType                      `xml:"ThirdPartyUSAddress"`
-	ThirdPartyForeignAddress       *ForeignAddressType                 `xml:"ThirdPartyForeignAddress"`
-	GamingManagerPersonNm          PersonNameType                      `xml:"GamingManagerPersonNm"`
-	GamingManagerBusinessName      *BusinessNameType                   `xml:"GamingManagerBusinessName"`
+	ThirdPartyPersonNm             PersonNameType                      `xml:"ThirdPartyPersonNm,omitempty" json:",omitempty" choice:"ThirdPartyPersonNm" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	ThirdPartyBusinessName         *BusinessNameType                   `xml:"ThirdPartyBusinessName,omitempty" json:",omitempty" choice:"ThirdPartyPersonNm" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	ThirdPartyUSAddress            *USAddressType                      `xml:"ThirdPartyUSAddress,omitempty" json:",omitempty" choice:"ThirdPartyUSAddress" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	ThirdPartyForeignAddress       *ForeignAddressType                 `xml:"ThirdPartyForeignAddress,omitempty" json:",omitempty" choice:"ThirdPartyUSAddress" choiceIf:"CntrctWith3rdPrtyForGameRevInd"`
+	GamingManagerPersonNm          PersonNameType                      `xml:"GamingManagerPersonNm,omitempty" json:",omitempty" choice:"GamingManagerPersonNm" choiceIf:"GamingInformationGrp"`
+	GamingManagerBusinessName      *BusinessNameType                   `xml:"GamingManagerBusinessName,omitempty" json:",omitempty" choice:"GamingManagerPersonNm" choiceIf:"GamingInformationGrp"`
 	GamingManagerCompensationAmt   int                                 `xml:"GamingManagerCompensationAmt,omitempty" json:",omitempty"`
 	GamingManagerServicesProvTxt   string                              `xml:"GamingManagerServicesProvTxt,omitempty" json:",omitempty"`
 	GamingManagerIsDirectorOfcrInd CheckboxType                        `xml:"GamingManagerIsDirectorOfcrInd,omitempty" json:",omitempty"`
@@ -806,21 +806,21 @@ func (r IRS990ScheduleGType) Validate() error {
 type IRS990ScheduleH struct {
 	FinancialAssistancePolicyInd   bool                            `xml:"FinancialAssistancePolicyInd,omitempty" json:",omitempty"`
 	WrittenPolicyInd               bool                            `xml:"WrittenPolicyInd,omitempty" json:",omitempty"`
-	AllHospitalsPolicyInd          CheckboxType                    `xml:"AllHospitalsPolicyInd"`
-	MostHospitalsPolicyInd         CheckboxType                    `xml:"MostHospitalsPolicyInd"`
-	IndivHospitalTailoredPolicyInd CheckboxType                    `xml:"IndivHospitalTailoredPolicyInd"`
+	AllHospitalsPolicyInd          CheckboxType                    `xml:"AllHospitalsPolicyInd,omitempty" json:",omitempty" choice:"AllHospitalsPolicyInd,optional"`
+	MostHospitalsPolicyInd         CheckboxType                    `xml:"MostHospitalsPolicyInd,omitempty" json:",omitempty" choice:"AllHospitalsPolicyInd,optional"`
+	IndivHospitalTailoredPolicyInd CheckboxType                    `xml:"IndivHospitalTailoredPolicyInd,omitempty" json:",omitempty" choice:"AllHospitalsPolicyInd,optional"`
 	FPGReferenceFreeCareInd        bool                            `xml:"FPGReferenceFreeCareInd,omitempty" json:",omitempty"`
-	Percent100Ind                  CheckboxType                    `xml:"Percent100Ind"`
-	Percent150Ind                  CheckboxType                    `xml:"Percent150Ind"`
-	Percent200Ind                  CheckboxType                    `xml:"Percent200Ind"`
-	FreeCareOthPercentageGrp       *FreeCareOthPercentageGrp       `xml:"FreeCareOthPercentageGrp"`
+	Percent100Ind                  CheckboxType                    `xml:"Percent100Ind,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
+	Percent150Ind                  CheckboxType                    `xml:"Percent150Ind,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
+	Percent200Ind                  CheckboxType                    `xml:"Percent200Ind,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
+	FreeCareOthPercentageGrp       *FreeCareOthPercentageGrp       `xml:"FreeCareOthPercentageGrp,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
 	FPGReferenceDiscountedCareInd  bool                            `xml:"FPGReferenceDiscountedCareInd,omitempty" json:",omitempty"`
-	Percent200DInd                 CheckboxType                    `xml:"Percent200DInd"`
-	Percent250Ind                  CheckboxType                    `xml:"Percent250Ind"`
-	Percent300Ind                  CheckboxType                    `xml:"Percent300Ind"`
-	Percent350Ind                  CheckboxType                    `xml:"Percent350Ind"`
-	Percent400Ind                  CheckboxType                    `xml:"Percent400Ind"`
-	DiscountedCareOthPercentageGrp *DiscountedCareOthPercentageGrp `xml:"DiscountedCareOthPercentageGrp"`
+	Percent200DInd                 CheckboxType                    `xml:"Percent200DInd,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent250Ind                  CheckboxType                    `xml:"Percent250Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent300Ind                  CheckboxType                    `xml:"Percent300Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent350Ind                  CheckboxType                    `xml:"Percent350Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent400Ind                  CheckboxType                    `xml:"Percent400Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	DiscountedCareOthPercentageGrp *DiscountedCareOthPercentageGrp `xml:"DiscountedCareOthPercentageGrp,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
 	FreeCareMedicallyIndigentInd   bool                            `xml:"FreeCareMedicallyIndigentInd,omitempty" json:",omitempty"`
 	FinancialAssistanceBudgetInd   bool                            `xml:"FinancialAssistanceBudgetInd,omitempty" json:",omitempty"`
 	ExpensesExceedBudgetInd        bool                            `xml:"ExpensesExceedBudgetInd,omitempty" json:",omitempty"`
@@ -879,21 +879,21 @@ func (r IRS990ScheduleH) Validate() error {
 type IRS990ScheduleHType struct {
 	FinancialAssistancePolicyInd   bool                            `xml:"FinancialAssistancePolicyInd,omitempty" json:",omitempty"`
 	WrittenPolicyInd               bool                            `xml:"WrittenPolicyInd,omitempty" json:",omitempty"`
-	AllHospitalsPolicyInd          CheckboxType                    `xml:"AllHospitalsPolicyInd"`
-	MostHospitalsPolicyInd         CheckboxType                    `xml:"MostHospitalsPolicyInd"`
-	IndivHospitalTailoredPolicyInd CheckboxType                    `xml:"IndivHospitalTailoredPolicyInd"`
+	AllHospitalsPolicyInd          CheckboxType                    `xml:"AllHospitalsPolicyInd,omitempty" json:",omitempty" choice:"AllHospitalsPolicyInd,optional"`
+	MostHospitalsPolicyInd         CheckboxType                    `xml:"MostHospitalsPolicyInd,omitempty" json:",omitempty" choice:"AllHospitalsPolicyInd,optional"`
+	IndivHospitalTailoredPolicyInd CheckboxType                    `xml:"IndivHospitalTailoredPolicyInd,omitempty" json:",omitempty" choice:"AllHospitalsPolicyInd,optional"`
 	FPGReferenceFreeCareInd        bool                            `xml:"FPGReferenceFreeCareInd,omitempty" json:",omitempty"`
-	Percent100Ind                  CheckboxType                    `xml:"Percent100Ind"`
-	Percent150Ind                  CheckboxType                    `xml:"Percent150Ind"`
-	Percent200Ind                  CheckboxType                    `xml:"Percent200Ind"`
-	FreeCareOthPercentageGrp       *FreeCareOthPercentageGrp       `xml:"FreeCareOthPercentageGrp"`
+	Percent100Ind                  CheckboxType                    `xml:"Percent100Ind,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
+	Percent150Ind                  CheckboxType                    `xml:"Percent150Ind,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
+	Percent200Ind                  CheckboxType                    `xml:"Percent200Ind,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
+	FreeCareOthPercentageGrp       *FreeCareOthPercentageGrp       `xml:"FreeCareOthPercentageGrp,omitempty" json:",omitempty" choice:"Percent100Ind" choiceIf:"FPGReferenceFreeCareInd"`
 	FPGReferenceDiscountedCareInd  bool                            `xml:"FPGReferenceDiscountedCareInd,omitempty" json:",omitempty"`
-	Percent200DInd                 CheckboxType                    `xml:"Percent200DInd"`
-	Percent250Ind                  CheckboxType                    `xml:"Percent250Ind"`
-	Percent300Ind                  CheckboxType                    `xml:"Percent300Ind"`
-	Percent350Ind                  CheckboxType                    `xml:"Percent350Ind"`
-	Percent400Ind                  CheckboxType                    `xml:"Percent400Ind"`
-	DiscountedCareOthPercentageGrp *DiscountedCareOthPercentageGrp `xml:"DiscountedCareOthPercentageGrp"`
+	Percent200DInd                 CheckboxType                    `xml:"Percent200DInd,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent250Ind                  CheckboxType                    `xml:"Percent250Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent300Ind                  CheckboxType                    `xml:"Percent300Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent350Ind                  CheckboxType                    `xml:"Percent350Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	Percent400Ind                  CheckboxType                    `xml:"Percent400Ind,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
+	DiscountedCareOthPercentageGrp *DiscountedCareOthPercentageGrp `xml:"DiscountedCareOthPercentageGrp,omitempty" json:",omitempty" choice:"Percent200DInd" choiceIf:"FPGReferenceDiscountedCareInd"`
 	FreeCareMedicallyIndigentInd   bool                            `xml:"FreeCareMedicallyIndigentInd,omitempty" json:",omitempty"`
 	FinancialAssistanceBudgetInd   bool                            `xml:"FinancialAssistanceBudgetInd,omitempty" json:",omitempty"`
 	ExpensesExceedBudgetInd        bool                            `xml:"ExpensesExceedBudgetInd,omitempty" json:",omitempty"`
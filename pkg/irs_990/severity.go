@@ -0,0 +1,116 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+// Error categories of the acknowledgement validation groups
+var (
+	missingDataCategory   = "Missing Data"
+	dataMismatchCategory  = "Data Mismatch"
+	unsupportedCategory   = "Unsupported"
+	incorrectDataCategory = "Incorrect Data"
+)
+
+func issueCategory(err error) string {
+	switch {
+	case errors.Is(err, utils.ErrMissingElement), errors.Is(err, utils.ErrMissingChoice):
+		return missingDataCategory
+	case errors.Is(err, ErrMismatchedManifest), errors.Is(err, ErrMismatchedManifestPeriod),
+		errors.Is(err, ErrMismatchedNameControl), errors.Is(err, ErrMismatchedDocumentCnt),
+		errors.Is(err, ErrMismatchedBinaryAttachmentCnt), errors.Is(err, ErrMismatchedTaxYr):
+		return dataMismatchCategory
+	case errors.Is(err, utils.ErrUnsupportedElement), errors.Is(err, ErrUnknownReturnVersion):
+		return unsupportedCategory
+	}
+	return incorrectDataCategory
+}
+
+// ValidationLists converts a validation result into the validation error and alert lists of an acknowledgement.
+// Issues without a document id are reported against documentId, a list is nil when it has no issues.
+func ValidationLists(result utils.ValidationResult, documentId IdType) (*ValidationErrorListType, *ValidationAlertListType) {
+	var errorList *ValidationErrorListType
+	var alertList *ValidationAlertListType
+
+	for _, issue := range result.Issues {
+		id := documentId
+		if len(issue.DocumentId) > 0 {
+			id = IdType(issue.DocumentId)
+		}
+		message := ""
+		if issue.Err != nil {
+			message = issue.Err.Error()
+		}
+
+		if issue.Severity.IsReject() {
+			if errorList == nil {
+				errorList = &ValidationErrorListType{}
+			}
+			errorList.ErrorCnt++
+			errorList.ValidationErrorGrp = append(errorList.ValidationErrorGrp, ValidationErrorGrp{
+				DocumentId:      id,
+				XpathContentTxt: issue.XpathContentTxt,
+				ErrorCategoryCd: issueCategory(issue.Err),
+				ErrorMessageTxt: message,
+				RuleNum:         issue.RuleNum,
+				SeverityCd:      string(issue.Severity),
+				FieldValueTxt:   issue.FieldValueTxt,
+				ErrorId:         errorList.ErrorCnt,
+			})
+			continue
+		}
+
+		if alertList == nil {
+			alertList = &ValidationAlertListType{}
+		}
+		alertList.AlertCnt++
+		alertList.ValidationAlertGrp = append(alertList.ValidationAlertGrp, ValidationAlertGrp{
+			DocumentId:      id,
+			XpathContentTxt: issue.XpathContentTxt,
+			AlertCategoryCd: issueCategory(issue.Err),
+			AlertMessageTxt: message,
+			RuleNum:         issue.RuleNum,
+			SeverityCd:      string(issue.Severity),
+			FieldValueTxt:   issue.FieldValueTxt,
+			AlertId:         alertList.AlertCnt,
+		})
+	}
+
+	return errorList, alertList
+}
+
+// ValidationResult returns the validation errors and alerts of the acknowledgement as a validation result
+func (r Acknowledgement) ValidationResult() utils.ValidationResult {
+	var result utils.ValidationResult
+	if r.ValidationErrorList != nil {
+		for _, grp := range r.ValidationErrorList.ValidationErrorGrp {
+			result.Issues = append(result.Issues, utils.ValidationIssue{
+				Severity:        utils.Severity(grp.SeverityCd),
+				DocumentId:      string(grp.DocumentId),
+				XpathContentTxt: grp.XpathContentTxt,
+				RuleNum:         grp.RuleNum,
+				FieldValueTxt:   grp.FieldValueTxt,
+				Err:             errors.New(grp.ErrorMessageTxt),
+			})
+		}
+	}
+	if r.ValidationAlertList != nil {
+		for _, grp := range r.ValidationAlertList.ValidationAlertGrp {
+			result.Issues = append(result.Issues, utils.ValidationIssue{
+				Severity:        utils.Severity(grp.SeverityCd),
+				DocumentId:      string(grp.DocumentId),
+				XpathContentTxt: grp.XpathContentTxt,
+				RuleNum:         grp.RuleNum,
+				FieldValueTxt:   grp.FieldValueTxt,
+				Err:             errors.New(grp.AlertMessageTxt),
+			})
+		}
+	}
+	return result
+}
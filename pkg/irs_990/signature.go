@@ -0,0 +1,140 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMissingOfficerPIN is given when a PIN signature doesn't have the PIN of the officer
+	ErrMissingOfficerPIN = errors.New("PIN signature requires the officer PIN")
+	// ErrMissingPINEnteredBy is given when the officer PIN doesn't say who entered it
+	ErrMissingPINEnteredBy = errors.New("officer PIN requires PINEnteredByCd")
+	// ErrMissingPractitionerPIN is given when the practitioner PIN method doesn't have the EFIN and PIN of the ERO
+	ErrMissingPractitionerPIN = errors.New("practitioner PIN requires the practitioner EFIN and PIN group")
+	// ErrMismatchedPractitionerEFIN is given when the practitioner EFIN isn't the originator EFIN
+	ErrMismatchedPractitionerEFIN = errors.New("practitioner EFIN doesn't match the originator EFIN")
+	// ErrConflictingSignature is given when a return is signed by PIN and by a Form 8453 attachment
+	ErrConflictingSignature = errors.New("signature by Form 8453 can't have a PIN")
+	// ErrMissingPreparerPTIN is given when a paid preparer doesn't have a PTIN
+	ErrMissingPreparerPTIN = errors.New("paid preparer requires a PTIN")
+	// ErrMissingPreparerFirmEIN is given when a paid preparer doesn't have the EIN of the firm
+	ErrMissingPreparerFirmEIN = errors.New("paid preparer requires a firm EIN")
+	// ErrMissingPreparer is given when the preparer firm is given without the paid preparer
+	ErrMissingPreparer = errors.New("preparer firm requires the paid preparer")
+	// ErrMissingSignature is given when the return has no e-file signature
+	ErrMissingSignature = errors.New("return has no e-file signature")
+)
+
+// E-file signature methods
+var (
+	SelfSelectPINMethod   = "Self-Select PIN"
+	PractitionerPINMethod = "Practitioner PIN"
+	Form8453Method        = "Form 8453"
+)
+
+var (
+	pinNumberOption        SignatureOptionCd = "PIN Number"
+	binary8453Option       SignatureOptionCd = "Binary Attachment 8453 Signature Document"
+	pinEnteredByEROCd      PINEnteredByCd    = "ERO"
+	pinEnteredByTaxpayerCd PINEnteredByCd    = "Taxpayer"
+)
+
+// SignatureReadiness is the e-file signature readiness report of a return
+type SignatureReadiness struct {
+	// Method is the signature method, empty when the return has no signature
+	Method string
+	// PaidPreparer is set when the return is prepared by a paid preparer
+	PaidPreparer bool
+	// Ready is set when the return can be signed and filed as it is
+	Ready bool
+	// Problems are the missing or conflicting signature and preparer data
+	Problems []error
+}
+
+// SignatureMethod returns the e-file signature method of the header, empty when the header has no signature.
+// The practitioner PIN method is used when the ERO enters the PIN or the practitioner PIN group is given,
+// the self-select PIN method is used for other PIN signatures.
+func (r ReturnHeaderType) SignatureMethod() string {
+	switch {
+	case r.SignatureOptionCd != nil && *r.SignatureOptionCd == binary8453Option:
+		return Form8453Method
+	case r.OriginatorGrp.PractitionerPINGrp != nil, r.PINEnteredByCd != nil && *r.PINEnteredByCd == pinEnteredByEROCd:
+		return PractitionerPINMethod
+	case r.BusinessOfficerGrp.TaxpayerPIN != nil, r.PINEnteredByCd != nil && *r.PINEnteredByCd == pinEnteredByTaxpayerCd,
+		r.SignatureOptionCd != nil && *r.SignatureOptionCd == pinNumberOption:
+		return SelfSelectPINMethod
+	}
+	return ""
+}
+
+// SignatureProblems reports the signature and paid preparer data that don't fit together
+func (r ReturnHeaderType) SignatureProblems() []error {
+	var problems []error
+	officerPIN := r.BusinessOfficerGrp.TaxpayerPIN
+	practitioner := r.OriginatorGrp.PractitionerPINGrp
+
+	switch r.SignatureMethod() {
+	case Form8453Method:
+		if officerPIN != nil || practitioner != nil {
+			problems = append(problems, ErrConflictingSignature)
+		}
+	case PractitionerPINMethod:
+		if practitioner == nil || len(practitioner.EFIN) == 0 || len(practitioner.PIN) == 0 {
+			problems = append(problems, ErrMissingPractitionerPIN)
+		} else if practitioner.EFIN != r.OriginatorGrp.EFIN {
+			problems = append(problems, fmt.Errorf("%w: %s, originator %s", ErrMismatchedPractitionerEFIN, practitioner.EFIN, r.OriginatorGrp.EFIN))
+		}
+		if officerPIN == nil {
+			problems = append(problems, ErrMissingOfficerPIN)
+		} else if r.PINEnteredByCd == nil {
+			problems = append(problems, ErrMissingPINEnteredBy)
+		}
+	case SelfSelectPINMethod:
+		if officerPIN == nil {
+			problems = append(problems, ErrMissingOfficerPIN)
+		} else if r.PINEnteredByCd == nil {
+			problems = append(problems, ErrMissingPINEnteredBy)
+		}
+	}
+
+	if r.PreparerPersonGrp != nil {
+		if len(r.PreparerPersonGrp.PTIN) == 0 {
+			problems = append(problems, ErrMissingPreparerPTIN)
+		}
+		if r.PreparerFirmGrp == nil || len(r.PreparerFirmGrp.PreparerFirmEIN) == 0 {
+			problems = append(problems, ErrMissingPreparerFirmEIN)
+		}
+	} else if r.PreparerFirmGrp != nil {
+		problems = append(problems, ErrMissingPreparer)
+	}
+
+	return problems
+}
+
+// ValidateSignature checks the combinations of the signature and paid preparer data, a return without signature is valid
+func (r ReturnHeaderType) ValidateSignature() error {
+	if problems := r.SignatureProblems(); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// SignatureReadiness reports whether the return has a complete e-file signature
+func (r *Return) SignatureReadiness() SignatureReadiness {
+	header := r.ReturnHeader
+	readiness := SignatureReadiness{
+		Method:       header.SignatureMethod(),
+		PaidPreparer: header.PreparerPersonGrp != nil,
+		Problems:     header.SignatureProblems(),
+	}
+	if len(readiness.Method) == 0 {
+		readiness.Problems = append([]error{ErrMissingSignature}, readiness.Problems...)
+	}
+	readiness.Ready = len(readiness.Problems) == 0
+	return readiness
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package irs_990
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	// ErrExhaustedSequence is given when all submission ids of an EFIN and day were generated
+	ErrExhaustedSequence = errors.New("submission ids of the day are exhausted")
+	// ErrMissingSequenceStore is given when a submission id generator is created without a sequence store
+	ErrMissingSequenceStore = errors.New("submission id generator needs a sequence store")
+)
+
+var (
+	submissionIdSuffixLen = 7
+	// maxSubmissionSequence is the largest sequence number that fits in the suffix
+	maxSubmissionSequence = uint64(78364164095) // 36^7 - 1
+)
+
+// SequenceStore reserves the sequence numbers of the submission ids.
+// A number given by Next is never given again for the key.
+type SequenceStore interface {
+	// Next reserves and returns the next sequence number of the key, the first number is 0
+	Next(key string) (uint64, error)
+}
+
+// SubmissionIdGenerator generates submission ids: the EFIN, the date as ccyyddd
+// and a sequence number as 7 lower case alphanumeric characters
+type SubmissionIdGenerator struct {
+	EFIN  EFINType
+	Store SequenceStore
+}
+
+// NewSubmissionIdGenerator returns a generator of the EFIN reserving its numbers in the store
+func NewSubmissionIdGenerator(efin EFINType, store SequenceStore) (*SubmissionIdGenerator, error) {
+	if err := efin.Validate(); err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, ErrMissingSequenceStore
+	}
+	return &SubmissionIdGenerator{EFIN: efin, Store: store}, nil
+}
+
+// Next returns a new submission id of the date
+func (g *SubmissionIdGenerator) Next(date time.Time) (SubmissionIdType, error) {
+	prefix := fmt.Sprintf("%s%04d%03d", g.EFIN, date.Year(), date.YearDay())
+	seq, err := g.Store.Next(prefix)
+	if err != nil {
+		return "", err
+	}
+	if seq > maxSubmissionSequence {
+		return "", fmt.Errorf("%w: %s", ErrExhaustedSequence, prefix)
+	}
+	suffix := strconv.FormatUint(seq, 36)
+	return SubmissionIdType(prefix + strings.Repeat("0", submissionIdSuffixLen-len(suffix)) + suffix), nil
+}
+
+// MemorySequenceStore is a sequence store in memory, its numbers are reused after a restart
+type MemorySequenceStore struct {
+	mu   sync.Mutex
+	next map[string]uint64
+}
+
+// NewMemorySequenceStore returns an empty sequence store
+func NewMemorySequenceStore() *MemorySequenceStore {
+	return &MemorySequenceStore{next: map[string]uint64{}}
+}
+
+// Next reserves and returns the next sequence number of the key
+func (s *MemorySequenceStore) Next(key string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seq := s.next[key]
+	s.next[key] = seq + 1
+	return seq, nil
+}
+
+// FileSequenceStore is a sequence store in a json file. A number is written to the file
+// before it's given, so numbers aren't reused after a restart of the process. The file
+// is locked while a number is reserved, so processes can share it. The data file can't be
+// locked itself as it's replaced on each write, a lock file next to it is used.
+type FileSequenceStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSequenceStore returns the sequence store of the file, the file is created by the first Next
+func NewFileSequenceStore(path string) *FileSequenceStore {
+	return &FileSequenceStore{path: path}
+}
+
+// Next reserves and returns the next sequence number of the key
+func (s *FileSequenceStore) Next(key string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := utils.LockFile(s.path + ".lock")
+	if err != nil {
+		return 0, fmt.Errorf("sequence store %s: %w", s.path, err)
+	}
+	defer unlock()
+
+	next := map[string]uint64{}
+	buf, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &next); err != nil {
+			return 0, fmt.Errorf("sequence store %s: %w", s.path, err)
+		}
+	}
+
+	seq := next[key]
+	next[key] = seq + 1
+	if buf, err = json.Marshal(next); err != nil {
+		return 0, err
+	}
+	if err = utils.WriteFileAtomic(s.path, buf); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
@@ -13,6 +13,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/moov-io/1120x/pkg/codelist"
 	"github.com/moov-io/1120x/pkg/utils"
 )
 
@@ -20,13 +21,19 @@ import (
 type AllCountriesType string
 
 func (r AllCountriesType) Validate() error {
-	return nil
+	if r == "US" || codelist.IsCountry(string(r)) {
+		return nil
+	}
+	return errors.New("AllCountriesType is invalid")
 }
 
 // May be one of All States
 type AllStatesCd string
 
 func (r AllStatesCd) Validate() error {
+	if r != "All States" {
+		return errors.New("AllStatesCd is invalid")
+	}
 	return nil
 }
 
@@ -52,12 +59,11 @@ func (r AlphaNumericType) Validate() error {
 	return nil
 }
 
-// Must match the pattern [A-Za-z0-9\-]+
+// Must match the pattern [A-Za-z0-9\-]+, may be no more than 17 items long, must have a digit and can't be all zeros
 type BankAccountNumberType string
 
 func (r BankAccountNumberType) Validate() error {
-	reg := regexp.MustCompile(`[A-Za-z0-9\-]+`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidBankAccountNumber(string(r)) {
 		return errors.New("BankAccountNumberType is invalid")
 	}
 	return nil
@@ -192,41 +198,15 @@ func (r ConsortiumType) Validate() error {
 	return errors.New("ConsortiumType is invalid")
 }
 
-// May be one of
-// AF, AX, AL, AG, AQ, AN, AO, AV, AY, AC, AR, AM, AA, AT, AS, AU, AJ, BF, BA, FQ, BG, BB, BO, BE, BH, BN, BD, BT, BL,
-// BK, BC, BV, BR, IO, VI, BX, BU, UV, BM, BY, CB, CM, CA, CV, CJ, CT, CD, CI, CH, KT, IP, CK, CO, CN, CF, CG, CW, CR,
-// CS, IV, HR, CU, UC, CY, EZ, DA, DX, DJ, DO, DR, TT, EC, EG, ES, EK, ER, EN, ET, FK, FO, FM, FJ, FI, FR, FP, FS, GB,
-// GA, GG, GM, GH, GI, GR, GL, GJ, GQ, GT, GK, GV, PU, GY, HA, HM, VT, HO, HK, HQ, HU, IC, IN, ID, IR, IZ, EI, IS, IT,
-// JM, JN, JA, DQ, JE, JQ, JO, KZ, KE, KQ, KR, KN, KS, KV, KU, KG, LA, LG, LE, LT, LI, LY, LS, LH, LU, MC, MK, MA, MI,
-// MY, MV, ML, MT, IM, RM, MR, MP, MX, MQ, MD, MN, MG, MJ, MH, MO, MZ, WA, NR, BQ, NP, NL, NC, NZ, NU, NG, NI, NE, NF,
-// CQ, NO, MU, OC, PK, PS, LQ, PM, PP, PF, PA, PE, RP, PC, PL, PO, RQ, QA, RO, RS, RW, TB, RN, WS, SM, TP, SA, SG, RI,
-// SE, SL, SN, NN, LO, SI, BP, SO, SF, SX, OD, SP, PG, CE, SH, SC, ST, SB, VC, SU, NS, SV, WZ, SW, SZ, SY, TW, TI, TZ,
-// TH, TO, TL, TN, TD, TS, TU, TX, TK, TV, UG, UP, AE, UK, UY, UZ, NH, VE, VM, VQ, WQ, WF, WI, YM, ZA, ZI
+// Must be one of the current country codes of the IRS code list of any tax year (see codelist package),
+// Return.ValidateCodeLists checks the list of the tax year of the return
 type CountryType string
 
 func (r CountryType) Validate() error {
-	for _, vv := range []string{
-		"AF", "AX", "AL", "AG", "AQ", "AN", "AO", "AV", "AY", "AC", "AR", "AM", "AA", "AT", "AS", "AU", "AJ", "BF",
-		"BA", "FQ", "BG", "BB", "BO", "BE", "BH", "BN", "BD", "BT", "BL", "BK", "BC", "BV", "BR", "IO", "VI", "BX",
-		"BU", "UV", "BM", "BY", "CB", "CM", "CA", "CV", "CJ", "CT", "CD", "CI", "CH", "KT", "IP", "CK", "CO", "CN",
-		"CF", "CG", "CW", "CR", "CS", "IV", "HR", "CU", "UC", "CY", "EZ", "DA", "DX", "DJ", "DO", "DR", "TT", "EC",
-		"EG", "ES", "EK", "ER", "EN", "ET", "FK", "FO", "FM", "FJ", "FI", "FR", "FP", "FS", "GB", "GA", "GG", "GM",
-		"GH", "GI", "GR", "GL", "GJ", "GQ", "GT", "GK", "GV", "PU", "GY", "HA", "HM", "VT", "HO", "HK", "HQ", "HU",
-		"IC", "IN", "ID", "IR", "IZ", "EI", "IS", "IT", "JM", "JN", "JA", "DQ", "JE", "JQ", "JO", "KZ", "KE", "KQ",
-		"KR", "KN", "KS", "KV", "KU", "KG", "LA", "LG", "LE", "LT", "LI", "LY", "LS", "LH", "LU", "MC", "MK", "MA",
-		"MI", "MY", "MV", "ML", "MT", "IM", "RM", "MR", "MP", "MX", "MQ", "MD", "MN", "MG", "MJ", "MH", "MO", "MZ",
-		"WA", "NR", "BQ", "NP", "NL", "NC", "NZ", "NU", "NG", "NI", "NE", "NF", "CQ", "NO", "MU", "OC", "PK", "PS",
-		"LQ", "PM", "PP", "PF", "PA", "PE", "RP", "PC", "PL", "PO", "RQ", "QA", "RO", "RS", "RW", "TB", "RN", "WS",
-		"SM", "TP", "SA", "SG", "RI", "SE", "SL", "SN", "NN", "LO", "SI", "BP", "SO", "SF", "SX", "OD", "SP", "PG",
-		"CE", "SH", "SC", "ST", "SB", "VC", "SU", "NS", "SV", "WZ", "SW", "SZ", "SY", "TW", "TI", "TZ", "TH", "TO",
-		"TL", "TN", "TD", "TS", "TU", "TX", "TK", "TV", "UG", "UP", "AE", "UK", "UY", "UZ", "NH", "VE", "VM", "VQ",
-		"WQ", "WF", "WI", "YM", "ZA", "ZI",
-	} {
-		if reflect.DeepEqual(string(r), vv) {
-			return nil
-		}
+	if !codelist.IsCountry(string(r)) {
+		return errors.New("CountryType is invalid")
 	}
-	return errors.New("CountryType is invalid")
+	return nil
 }
 
 // Base type for a date
@@ -314,30 +294,31 @@ func (r DocumentTypeCd) Validate() error {
 type EFINType string
 
 func (r EFINType) Validate() error {
-	reg := regexp.MustCompile(`[0-9]{6}`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidEFIN(string(r)) {
 		return errors.New("EFINType is invalid")
 	}
 	return nil
 }
 
-// Must match the pattern [0-9]{9}
+// Must match the pattern [0-9]{9} with a valid campus prefix, EINs beginning with 00 are ATS test EINs (Publication 4164)
 type EINType string
 
 func (r EINType) Validate() error {
-	reg := regexp.MustCompile(`[0-9]{9}`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidEIN(string(r)) {
 		return errors.New("EINType is invalid")
 	}
 	return nil
 }
 
+func (r EINType) IsTestIdentifier() bool {
+	return IsTestEIN(string(r))
+}
+
 // Must match the pattern [0-9]{5}
 type ETINType string
 
 func (r ETINType) Validate() error {
-	reg := regexp.MustCompile(`[0-9]{5}`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidETIN(string(r)) {
 		return errors.New("ETINType is invalid")
 	}
 	return nil
@@ -688,12 +669,11 @@ func (r PINType) Validate() error {
 	return nil
 }
 
-// Must match the pattern P[0-9]{8}
+// Must match the pattern P[0-9]{8}, the digits can't be all zeros
 type PTINType string
 
 func (r PTINType) Validate() error {
-	reg := regexp.MustCompile(`P[0-9]{8}`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidPTIN(string(r)) {
 		return errors.New("PTINType is invalid")
 	}
 	return nil
@@ -819,22 +799,21 @@ func (r ReturnTypeCd) Validate() error {
 }
 
 // Must match the pattern (01|02|03|04|05|06|07|08|09|10|11|12|21|22|23|24|25|26|27|28|29|30|31|32)[0-9]{7}
+// and have a valid ABA check digit
 type RoutingTransitNumberType string
 
 func (r RoutingTransitNumberType) Validate() error {
-	reg := regexp.MustCompile(`(01|02|03|04|05|06|07|08|09|10|11|12|21|22|23|24|25|26|27|28|29|30|31|32)[0-9]{7}`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidRoutingNumber(string(r)) {
 		return errors.New("RoutingTransitNumberType is invalid")
 	}
 	return nil
 }
 
-// Must match the pattern [0-9]{9}
+// Must match the pattern [0-9]{9} and be a valid SSN or ITIN
 type SSNType string
 
 func (r SSNType) Validate() error {
-	reg := regexp.MustCompile(`[0-9]{9}`)
-	if !reg.MatchString(string(r)) {
+	if !IsValidSSN(string(r)) && !IsValidITIN(string(r)) {
 		return errors.New("SSNType is invalid")
 	}
 	return nil
@@ -855,7 +834,14 @@ func (r STINType) Validate() error {
 type SignatureOptionCd string
 
 func (r SignatureOptionCd) Validate() error {
-	return nil
+	for _, vv := range []string{
+		"PIN Number", "Binary Attachment 8453 Signature Document",
+	} {
+		if reflect.DeepEqual(string(r), vv) {
+			return nil
+		}
+	}
+	return errors.New("SignatureOptionCd is invalid")
 }
 
 // Must match the pattern [0-9]{10}
@@ -880,24 +866,15 @@ func (r SoftwareIdType) Validate() error {
 	return nil
 }
 
-// May be one of
-// AL, AK, AS, AZ, AR, CA, CO, MP, CT, DE, DC, FM, FL, GA, GU, HI, ID, IL, IN, IA, KS, KY, LA, ME, MH, MD, MA, MI, MN,
-// MS, MO, MT, NE, NV, NH, NJ, NM, NY, NC, ND, OH, OK, OR, PW, PA, PR, RI, SC, SD, TN, TX, VI, UT, VT, VA, WA, WV, WI,
-// WY, AA, AE, AP
+// Must be one of the state, territory or military codes of the IRS code list of any tax year (see codelist package),
+// Return.ValidateCodeLists checks the list of the tax year of the return
 type StateType string
 
 func (r StateType) Validate() error {
-	for _, vv := range []string{
-		"AL", "AK", "AS", "AZ", "AR", "CA", "CO", "MP", "CT", "DE", "DC", "FM", "FL", "GA", "GU", "HI", "ID", "IL",
-		"IN", "IA", "KS", "KY", "LA", "ME", "MH", "MD", "MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV", "NH", "NJ",
-		"NM", "NY", "NC", "ND", "OH", "OK", "OR", "PW", "PA", "PR", "RI", "SC", "SD", "TN", "TX", "VI", "UT", "VT",
-		"VA", "WA", "WV", "WI", "WY", "AA", "AE", "AP",
-	} {
-		if reflect.DeepEqual(string(r), vv) {
-			return nil
-		}
+	if !codelist.IsState(string(r)) {
+		return errors.New("StateType is invalid")
 	}
-	return errors.New("StateType is invalid")
+	return nil
 }
 
 // Must match the pattern [A-Za-z0-9]( ?[A-Za-z0-9\-/])*
@@ -978,8 +955,10 @@ func (t TimestampType) Validate() error {
 func (t *TimestampType) UnmarshalText(text []byte) error {
 	return (*xsdDateTime)(t).UnmarshalText(text)
 }
+
+// MarshalText writes the timestamp with its zone and without fractional seconds, as the schema requires
 func (t TimestampType) MarshalText() ([]byte, error) {
-	return xsdDateTime(t).MarshalText()
+	return []byte(time.Time(t).Format(time.RFC3339)), nil
 }
 
 // May be one of US, ES, ED, CS, CD, MS, MD, PS, PD, AS, AD, HS, HD
@@ -4,4 +4,186 @@
 
 package irs_990
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+// Version is the return version of the schemas the return structs are generated from
 const Version = "2015v2.0"
+
+var (
+	// ErrUnknownReturnVersion is given when the return version isn't in the version registry
+	ErrUnknownReturnVersion = errors.New("return version isn't supported")
+)
+
+// SchemaVersion describes a return version (returnVersion attribute) of the 990 e-file schemas
+type SchemaVersion struct {
+	// Version is the return version, ccyyv#.#
+	Version string
+	// TaxYr is the tax year of the version, see AcceptsTaxPeriod for the accepted tax periods
+	TaxYr int
+	// SchemaPackage is the name of the schema package released by the IRS
+	SchemaPackage string
+	// StylesheetDir is the directory of the stylesheets under data/mef/Stylesheets
+	StylesheetDir string
+	// UnsupportedElements are the elements of the return structs that don't exist in the version,
+	// given as "<parent element>/<element>"
+	UnsupportedElements []string
+}
+
+// Net asset elements of Part X. FASB ASU 2016-14 replaced the SFAS 117 elements by the donor
+// restriction elements in the 2018 schemas, the return structs model both sets.
+var (
+	sfas117Elements = []string{
+		"IRS990/OrganizationFollowsSFAS117Ind",
+		"IRS990/UnrestrictedNetAssetsGrp",
+		"IRS990/TemporarilyRstrNetAssetsGrp",
+		"IRS990/PermanentlyRstrNetAssetsGrp",
+		"IRS990/OrgDoesNotFollowSFAS117Ind",
+	}
+	fasb117Elements = []string{
+		"IRS990/OrganizationFollowsFASB117Ind",
+		"IRS990/NoDonorRestrictionNetAssetsGrp",
+		"IRS990/DonorRestrictionNetAssetsGrp",
+		"IRS990/OrgDoesNotFollowFASB117Ind",
+	}
+)
+
+// modeledVersion is the version of the schema package in data/xsd that the return structs are generated from
+var modeledVersion = SchemaVersion{
+	Version:             Version,
+	TaxYr:               2015,
+	SchemaPackage:       "efile990x_2015v2.0_09082015",
+	StylesheetDir:       "2015",
+	UnsupportedElements: fasb117Elements,
+}
+
+var (
+	versionsMu sync.RWMutex
+	versions   = map[string]SchemaVersion{}
+)
+
+func init() {
+	versions[modeledVersion.Version] = modeledVersion
+	for _, version := range []string{"2015v2.1", "2015v3.0"} {
+		registerVersion(version, 2015, fasb117Elements)
+	}
+	for _, version := range []string{"2016v3.0", "2016v3.1"} {
+		registerVersion(version, 2016, fasb117Elements)
+	}
+	for _, version := range []string{"2017v2.0", "2017v2.1", "2017v2.2", "2017v2.3"} {
+		registerVersion(version, 2017, fasb117Elements)
+	}
+	for _, version := range []string{"2018v3.0", "2018v3.1", "2018v3.2", "2018v3.3"} {
+		registerVersion(version, 2018, sfas117Elements)
+	}
+	for _, version := range []string{"2019v5.0", "2019v5.1", "2019v5.2"} {
+		registerVersion(version, 2019, sfas117Elements)
+	}
+	for _, version := range []string{"2020v1.0", "2020v1.1", "2020v1.2", "2020v1.3", "2020v2.0", "2020v3.0", "2020v4.0", "2020v4.1", "2020v4.2"} {
+		registerVersion(version, 2020, sfas117Elements)
+	}
+}
+
+// registerVersion registers a version of the 990x schema packages of the IRS, named efile990x_<version>
+func registerVersion(version string, taxYr int, unsupported []string) {
+	versions[version] = SchemaVersion{
+		Version:             version,
+		TaxYr:               taxYr,
+		SchemaPackage:       "efile990x_" + version,
+		StylesheetDir:       strconv.Itoa(taxYr),
+		UnsupportedElements: unsupported,
+	}
+}
+
+// RegisterVersion adds a return version to the registry, a registered version is replaced.
+// The elements of the version must be modeled by the return structs, the elements of the structs
+// that the version doesn't have are given by UnsupportedElements.
+func RegisterVersion(version SchemaVersion) error {
+	if len(version.Version) == 0 || version.TaxYr <= 0 {
+		return errors.New("return version and tax year are required")
+	}
+
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+	versions[version.Version] = version
+	return nil
+}
+
+// LookupVersion returns the registered return version
+func LookupVersion(version string) (SchemaVersion, error) {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+
+	registered, ok := versions[version]
+	if !ok {
+		return SchemaVersion{}, fmt.Errorf("%w: %s", ErrUnknownReturnVersion, version)
+	}
+	return registered, nil
+}
+
+// Versions returns the registered return versions in order
+func Versions() []string {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+
+	var list []string
+	for version := range versions {
+		list = append(list, version)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// AcceptsTaxPeriod reports whether the version accepts the returns of the tax period.
+// The form of a tax year is for the calendar year and the fiscal years that begin in the
+// tax year. A short period that begins in the next year may be filed on the form of the
+// tax year when the form of the next year isn't available yet, the version accepts it too.
+func (v SchemaVersion) AcceptsTaxPeriod(begin, end DateType) bool {
+	switch time.Time(begin).Year() {
+	case v.TaxYr:
+		return true
+	case v.TaxYr + 1:
+		return isShortPeriod(begin, end)
+	}
+	return false
+}
+
+// ValidateTaxPeriod checks that the version accepts the returns of the tax period
+func (v SchemaVersion) ValidateTaxPeriod(begin, end DateType) error {
+	if !v.AcceptsTaxPeriod(begin, end) {
+		return fmt.Errorf("%w: %s accepts the tax periods beginning in %d, tax period is %s - %s",
+			ErrUnacceptedTaxYr, v.Version, v.TaxYr, dateString(begin), dateString(end))
+	}
+	return nil
+}
+
+// ValidateElements checks that r has no elements that the version doesn't define
+func (v SchemaVersion) ValidateElements(r interface{}) error {
+	unsupported := make(map[string]bool, len(v.UnsupportedElements))
+	for _, element := range v.UnsupportedElements {
+		unsupported[element] = true
+	}
+	if err := utils.ValidateElements(r, unsupported); err != nil {
+		return fmt.Errorf("%s: %w", v.Version, err)
+	}
+	return nil
+}
+
+// UnregisterVersion removes a registered return version, the version the structs are generated from stays
+func UnregisterVersion(version string) {
+	if version == modeledVersion.Version {
+		return
+	}
+
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+	delete(versions, version)
+}
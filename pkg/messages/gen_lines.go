@@ -0,0 +1,170 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// gen_lines writes data/lines.json, the form lines and descriptions of the elements
+// taken from the LineNumber and Description annotations of the return schema. The
+// lines are keyed like the labels of the catalogs, by the parent type and the element.
+//
+// Run it with go generate after changing the schema or the structs of irs_990.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var schemaPath = filepath.Join("..", "..", "data", "xsd", "merged", "irs990x.xsd")
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+
+type node struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []node     `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+func (n node) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (n node) is(local string) bool {
+	return n.XMLName.Space == xsdNamespace && n.XMLName.Local == local
+}
+
+type line struct {
+	Line        string `json:"line"`
+	Description string `json:"description,omitempty"`
+}
+
+// schemaLines maps the schema types, and the elements with an anonymous type, to the lines of their elements
+type schemaLines map[string]map[string]line
+
+func (s schemaLines) collect(n node, owner string) {
+	switch {
+	case n.is("complexType") && len(n.attr("name")) > 0:
+		owner = n.attr("name")
+	case n.is("element") && len(n.attr("name")) > 0:
+		if doc, ok := documentation(n); ok && len(owner) > 0 {
+			if s[owner] == nil {
+				s[owner] = map[string]line{}
+			}
+			s[owner][n.attr("name")] = doc
+		}
+		for _, child := range n.Children {
+			if child.is("complexType") {
+				s.collect(child, n.attr("name"))
+			}
+		}
+		return
+	}
+	for _, child := range n.Children {
+		s.collect(child, owner)
+	}
+}
+
+func documentation(element node) (line, bool) {
+	var doc line
+	for _, annotation := range element.Children {
+		if !annotation.is("annotation") {
+			continue
+		}
+		for _, documentation := range annotation.Children {
+			for _, child := range documentation.Children {
+				text := strings.Join(strings.Fields(child.Text), " ")
+				switch child.XMLName.Local {
+				case "LineNumber":
+					doc.Line = text
+				case "Description":
+					doc.Description = text
+				}
+			}
+		}
+	}
+	return doc, len(doc.Line) > 0
+}
+
+// collectTypes adds the struct types reachable from typ by name
+func collectTypes(typ reflect.Type, types map[string]reflect.Type) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || types[typ.Name()] != nil {
+		return
+	}
+	types[typ.Name()] = typ
+	for i := 0; i < typ.NumField(); i++ {
+		collectTypes(typ.Field(i).Type, types)
+	}
+}
+
+func main() {
+	buf, err := os.ReadFile(schemaPath)
+	if err != nil {
+		fail(err)
+	}
+	var schema node
+	if err = xml.Unmarshal(buf, &schema); err != nil {
+		fail(err)
+	}
+	lines := schemaLines{}
+	lines.collect(schema, "")
+
+	types := map[string]reflect.Type{}
+	collectTypes(reflect.TypeOf(irs_990.Irs990File{}), types)
+
+	out := map[string]line{}
+	for name, typ := range types {
+		// the structs are named like their schema type, with or without the Type suffix
+		owner := lines[name]
+		if owner == nil {
+			owner = lines[name+"Type"]
+		}
+		if owner == nil {
+			owner = lines[strings.TrimSuffix(name, "Type")]
+		}
+		if owner == nil {
+			continue
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			element := utils.ElementName(typ.Field(i))
+			if doc, ok := owner[element]; ok {
+				out[name+"."+element] = doc
+			}
+		}
+	}
+
+	// maps are written with sorted keys
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(out); err != nil {
+		fail(err)
+	}
+	if err = os.WriteFile(filepath.Join("data", "lines.json"), data.Bytes(), 0o644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gen_lines:", err)
+	os.Exit(1)
+}
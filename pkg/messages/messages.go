@@ -0,0 +1,300 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package messages
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+// Message catalogs, one file per language
+//
+//go:embed data
+var dataFS embed.FS
+
+// Language of a message catalog
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+)
+
+var (
+	// ErrUnknownLanguage is given when hasn't a catalog of the language
+	ErrUnknownLanguage = errors.New("hasn't a catalog of the language")
+)
+
+// CodeOther is the code of errors that are neither element errors nor business rule errors
+var CodeOther = "Other"
+
+// Codes of the business rule errors, the templates of the catalogs are keyed by these codes
+var ruleCodes = []struct {
+	err  error
+	code string
+}{
+	{irs_990.ErrInvalidTaxPeriod, "InvalidTaxPeriod"},
+	{irs_990.ErrMismatchedTaxYr, "MismatchedTaxYr"},
+	{irs_990.ErrFutureReturnTs, "FutureReturnTs"},
+	{irs_990.ErrUnacceptedTaxYr, "UnacceptedTaxYr"},
+	{irs_990.ErrMismatchedManifestPeriod, "MismatchedManifestPeriod"},
+	{irs_990.ErrMismatchedManifest, "MismatchedManifest"},
+	{irs_990.ErrMismatchedDocumentCnt, "MismatchedDocumentCnt"},
+	{irs_990.ErrMismatchedBinaryAttachmentCnt, "MismatchedBinaryAttachmentCnt"},
+	{irs_990.ErrDuplicatedDocumentId, "DuplicatedDocumentId"},
+	{irs_990.ErrUnresolvedAttachmentLocation, "UnresolvedAttachmentLocation"},
+	{irs_990.ErrMissingOfficerPIN, "MissingOfficerPIN"},
+	{irs_990.ErrMissingPINEnteredBy, "MissingPINEnteredBy"},
+	{irs_990.ErrMissingPractitionerPIN, "MissingPractitionerPIN"},
+	{irs_990.ErrMismatchedPractitionerEFIN, "MismatchedPractitionerEFIN"},
+	{irs_990.ErrConflictingSignature, "ConflictingSignature"},
+	{irs_990.ErrMissingPreparerPTIN, "MissingPreparerPTIN"},
+	{irs_990.ErrMissingPreparerFirmEIN, "MissingPreparerFirmEIN"},
+	{irs_990.ErrMissingPreparer, "MissingPreparer"},
+	{irs_990.ErrMissingSignature, "MissingSignature"},
+	{irs_990.ErrUnknownReturnVersion, "UnknownReturnVersion"},
+	{irs_990.ErrDuplicateSubmissionId, "DuplicateSubmissionId"},
+	{irs_990.ErrDuplicateOriginal, "DuplicateOriginal"},
+	{irs_990.ErrMissingOriginal, "MissingOriginal"},
+	{irs_990.ErrMismatchedNameControl, "MismatchedNameControl"},
+	{irs_990.ErrCodeNotInTaxYr, "CodeNotInTaxYr"},
+	{irs_990.ErrInvalidAttachmentName, "InvalidAttachmentName"},
+	{irs_990.ErrNotPDFAttachment, "NotPDFAttachment"},
+	{irs_990.ErrDuplicatedAttachment, "DuplicatedAttachment"},
+	{irs_990.ErrUnreferencedAttachment, "UnreferencedAttachment"},
+}
+
+// RuleCode returns the code of a business rule error, CodeOther when err isn't a business rule error
+func RuleCode(err error) string {
+	for _, rule := range ruleCodes {
+		if errors.Is(err, rule.err) {
+			return rule.code
+		}
+	}
+	return CodeOther
+}
+
+var (
+	dataDir          = "data"
+	invalidFormatKey = "InvalidValueFormat"
+)
+
+// Catalog holds the message templates, format descriptions and form field labels of a language.
+//
+// Templates may use {label}, {value}, {format} and {detail}, labels are keyed
+// by "<parent type>.<element>". Rules describe the
+// checks of the validators beyond the pattern, keyed by the irs_990 rule names.
+// Lines hold the words of the form line references and the label template of
+// the elements without label, which may use {line} and {description}.
+type Catalog struct {
+	Messages map[string]string `json:"messages"`
+	Formats  map[string]string `json:"formats"`
+	Rules    map[string]string `json:"rules"`
+	Labels   map[string]string `json:"labels"`
+	Lines    map[string]string `json:"lines"`
+}
+
+// FormLine is the form line of an element, taken from the schema annotations by gen_lines.go
+type FormLine struct {
+	Line        string `json:"line"`
+	Description string `json:"description"`
+}
+
+//go:generate go run gen_lines.go
+
+// formLines are the form lines of the elements keyed like the labels
+var formLines = map[string]FormLine{}
+
+// Words of the form line references
+var lineWords = []string{"Part", "Section", "Line", "Column"}
+
+const (
+	lineLabelKey = "label"
+	lineBoxKey   = "Box"
+)
+
+// Message is a validation error rendered by a catalog
+type Message struct {
+	Code   string
+	Label  string
+	Value  string
+	Format string
+	Text   string
+	// Detail is the error text with the offending values, not localized
+	Detail string
+}
+
+var catalogs = map[Language]*Catalog{}
+
+func init() {
+	buf, err := dataFS.ReadFile(path.Join(dataDir, "lines.json"))
+	if err != nil {
+		panic(err)
+	}
+	if err = json.Unmarshal(buf, &formLines); err != nil {
+		panic(fmt.Sprintf("messages: form lines: %v", err))
+	}
+
+	for _, lang := range []Language{English, Spanish} {
+		buf, err := dataFS.ReadFile(path.Join(dataDir, string(lang)+".json"))
+		if err != nil {
+			panic(err)
+		}
+		catalog := &Catalog{}
+		if err = json.Unmarshal(buf, catalog); err != nil {
+			panic(fmt.Sprintf("messages: %s catalog: %v", lang, err))
+		}
+		catalogs[lang] = catalog
+	}
+}
+
+// Get returns the catalog of the language
+func Get(lang Language) (*Catalog, error) {
+	catalog, ok := catalogs[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLanguage, lang)
+	}
+	return catalog, nil
+}
+
+// Localize renders err in the language, English is used for unknown languages
+func Localize(err error, lang Language) Message {
+	catalog, catalogErr := Get(lang)
+	if catalogErr != nil {
+		catalog = catalogs[English]
+	}
+	return catalog.Message(err)
+}
+
+// Message renders err with the templates of the catalog
+func (c *Catalog) Message(err error) Message {
+	if err == nil {
+		return Message{}
+	}
+
+	message := Message{Code: RuleCode(err), Detail: err.Error()}
+	key := message.Code
+
+	var fieldErr *utils.FieldError
+	if errors.As(err, &fieldErr) {
+		message.Code = fieldErr.Code
+		message.Label = c.Label(fieldErr.Parent, fieldErr.Element)
+		message.Value = fieldErr.Value
+		key = fieldErr.Code
+		if fieldErr.Code == utils.CodeInvalidValue {
+			if format, ok := irs_990.ExpectedFormat(fieldErr.Type); ok {
+				message.Format = c.Format(format)
+				key = invalidFormatKey
+			}
+		}
+	}
+
+	template, ok := c.Messages[key]
+	if !ok {
+		template = c.Messages[CodeOther]
+	}
+	message.Text = strings.NewReplacer(
+		"{label}", message.Label,
+		"{value}", message.Value,
+		"{format}", message.Format,
+		"{detail}", message.Detail,
+	).Replace(template)
+	return message
+}
+
+// Label returns the form field label of an element. Elements without label of the catalog are labeled
+// by their form line, a choice by the line of its first element, elements without form line keep their name.
+// Element names are shared by many forms and schedules, so labels are only looked up with their parent type.
+func (c *Catalog) Label(parent, element string) string {
+	if label, ok := c.Labels[parent+"."+element]; ok {
+		return label
+	}
+	members := strings.Split(element, "|")
+	line, ok := formLines[parent+"."+members[0]]
+	if !ok {
+		return element
+	}
+	description := line.Description
+	if len(members) > 1 {
+		// the description is the one of the first element only
+		description = ""
+	}
+	label := strings.NewReplacer(
+		"{line}", c.FormLine(line.Line),
+		"{description}", description,
+	).Replace(c.Lines[lineLabelKey])
+	return strings.TrimSuffix(label, " — ")
+}
+
+// FormLine renders a form line reference of the schema, like "Part IV Section B Line 1a",
+// with the words of the catalog. A reference without words is a box of the form heading.
+func (c *Catalog) FormLine(ref string) string {
+	var parts []string
+	words := strings.Fields(strings.ReplaceAll(ref, ",", " "))
+	for i := 0; i < len(words); i++ {
+		if word, ok := c.lineWord(words[i]); ok && i+1 < len(words) {
+			parts = append(parts, word+" "+words[i+1])
+			i++
+			continue
+		}
+		if len(parts) == 0 {
+			parts = append(parts, words[i])
+			continue
+		}
+		parts[len(parts)-1] += " " + words[i]
+	}
+	if len(parts) == 1 && parts[0] == ref {
+		return c.Lines[lineBoxKey] + " " + ref
+	}
+	line := strings.Join(parts, ", ")
+	if len(line) > 0 {
+		first, size := utf8.DecodeRuneInString(line)
+		line = string(unicode.ToUpper(first)) + line[size:]
+	}
+	return line
+}
+
+func (c *Catalog) lineWord(word string) (string, bool) {
+	for _, lineWord := range lineWords {
+		if strings.EqualFold(word, lineWord) {
+			translated, ok := c.Lines[lineWord]
+			return translated, ok
+		}
+	}
+	return "", false
+}
+
+// Format describes the expected format of a value
+func (c *Catalog) Format(format irs_990.Format) string {
+	var parts []string
+	if len(format.Values) > 0 {
+		parts = append(parts, strings.ReplaceAll(c.Formats["values"], "{values}", strings.Join(format.Values, ", ")))
+	}
+	if len(format.Pattern) > 0 {
+		parts = append(parts, strings.ReplaceAll(c.Formats["pattern"], "{pattern}", format.Pattern))
+	}
+	for _, rule := range format.Rules {
+		if text, ok := c.Rules[rule]; ok {
+			parts = append(parts, text)
+		}
+	}
+	if format.MinLength > 0 {
+		parts = append(parts, strings.ReplaceAll(c.Formats["minLength"], "{length}", strconv.Itoa(format.MinLength)))
+	}
+	if format.MaxLength > 0 {
+		parts = append(parts, strings.ReplaceAll(c.Formats["maxLength"], "{length}", strconv.Itoa(format.MaxLength)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package messages
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadReturn(t *testing.T) *irs_990.Return {
+	buf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	ret := &irs_990.Return{}
+	assert.Equal(t, nil, xml.Unmarshal(buf, ret))
+	return ret
+}
+
+func TestCatalogs(t *testing.T) {
+	english, err := Get(English)
+	assert.Equal(t, nil, err)
+	spanish, err := Get(Spanish)
+	assert.Equal(t, nil, err)
+
+	// every language has the same messages and labels
+	for key := range english.Messages {
+		assert.Contains(t, spanish.Messages, key)
+	}
+	for key := range english.Labels {
+		assert.Contains(t, spanish.Labels, key)
+	}
+	for key := range english.Lines {
+		assert.Contains(t, spanish.Lines, key)
+	}
+	for _, rule := range []string{irs_990.RuleEINPrefix, irs_990.RuleSSNOrITIN, irs_990.RulePTINNotZero, irs_990.RuleRoutingCheckDigit, irs_990.RuleBankAccountNotZero} {
+		assert.Contains(t, english.Rules, rule)
+		assert.Contains(t, spanish.Rules, rule)
+	}
+
+	for _, rule := range ruleCodes {
+		assert.Contains(t, english.Messages, rule.code)
+		assert.Contains(t, spanish.Messages, rule.code)
+	}
+
+	_, err = Get("fr")
+	assert.True(t, errors.Is(err, ErrUnknownLanguage))
+}
+
+// collectTypes adds the struct types reachable from typ by name
+func collectTypes(typ reflect.Type, types map[string]reflect.Type) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || types[typ.Name()] != nil {
+		return
+	}
+	types[typ.Name()] = typ
+	for i := 0; i < typ.NumField(); i++ {
+		collectTypes(typ.Field(i).Type, types)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	types := map[string]reflect.Type{}
+	collectTypes(reflect.TypeOf(irs_990.Irs990File{}), types)
+
+	english, err := Get(English)
+	assert.Equal(t, nil, err)
+	for key := range english.Labels {
+		// labels are keyed by the parent type and name elements that can be reported
+		dot := strings.Index(key, ".")
+		assert.True(t, dot > 0, key)
+		parent, ok := types[key[:dot]]
+		assert.True(t, ok, key)
+		if !ok {
+			continue
+		}
+		for _, element := range strings.Split(key[dot+1:], "|") {
+			_, ok := parent.FieldByName(element)
+			assert.True(t, ok, key)
+		}
+	}
+	for key := range formLines {
+		dot := strings.Index(key, ".")
+		parent, ok := types[key[:dot]]
+		assert.True(t, ok, key)
+		if ok {
+			_, ok = parent.FieldByName(key[dot+1:])
+			assert.True(t, ok, key)
+		}
+	}
+
+	// elements without label are labeled by their form line
+	spanish, err := Get(Spanish)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Part I, line 12 — Total revenue - current year", english.Label("IRS990", "CYTotalRevenueAmt"))
+	assert.Equal(t, "Parte I, línea 12", spanish.Label("IRS990", "CYTotalRevenueAmt"))
+	assert.Equal(t, "Part I, line 2b(iv) — Gross receipts from activity", english.Label("FundraiserActivityInfoGrpType", "GrossReceiptsAmt"))
+	assert.Equal(t, "Part VII, Section B, line 1(A) — Address - US", english.Label("ContractorAddress", "USAddress"))
+	assert.Equal(t, "Parte VII, Sección B, línea 1(A)", spanish.Label("ContractorAddress", "USAddress"))
+	assert.Equal(t, "Box B — Indicates this return has an address change", english.Label("IRS990", "AddressChangeInd"))
+	assert.Equal(t, "Part III, line 16", english.Label("IRS990ScheduleG", "GamingManagerPersonNm|GamingManagerBusinessName"))
+
+	// elements without label or form line keep their name
+	assert.Equal(t, "CityNm", english.Label("USAddressType", "CityNm"))
+}
+
+func TestInvalidValueMessage(t *testing.T) {
+	ret := loadReturn(t)
+	ret.ReturnHeader.Filer.EIN = "12"
+	err := ret.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "EINType is invalid", err.Error())
+
+	message := Localize(err, English)
+	assert.Equal(t, utils.CodeInvalidValue, message.Code)
+	assert.Equal(t, "Box D — Employer identification number", message.Label)
+	assert.Equal(t, "12", message.Value)
+	assert.Equal(t, `Box D — Employer identification number: "12" is invalid, the expected format is a value matching [0-9]{9}, with a prefix assigned by an IRS campus or 00 for testing`, message.Text)
+
+	message = Localize(err, Spanish)
+	assert.Equal(t, `Casilla D — Número de identificación del empleador: "12" no es válido, el formato esperado es un valor que coincida con [0-9]{9}, con un prefijo asignado por un centro del IRS o 00 para pruebas`, message.Text)
+}
+
+func TestFormatRules(t *testing.T) {
+	format, ok := irs_990.ExpectedFormat("BankAccountNumberType")
+	assert.True(t, ok)
+	english, err := Get(English)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `a value matching [A-Za-z0-9\-]+, not all zeros, no more than 17 characters`, english.Format(format))
+
+	// rules without a description are left out
+	assert.Equal(t, "a value matching [0-9]{9}", english.Format(irs_990.Format{Pattern: "[0-9]{9}", Rules: []string{"unknown"}}))
+}
+
+func TestMissingElementMessage(t *testing.T) {
+	ret := loadReturn(t)
+	ret.ReturnHeader.SoftwareId = ""
+	err := ret.Validate()
+	assert.True(t, errors.Is(err, utils.ErrMissingElement))
+
+	assert.Equal(t, "Return header — Software ID is required", Localize(err, English).Text)
+	assert.Equal(t, "Encabezado de la declaración — Identificación del programa es obligatorio", Localize(err, Spanish).Text)
+
+	ret = loadReturn(t)
+	ret.ReturnHeader.Filer.USAddress, ret.ReturnHeader.Filer.ForeignAddress = nil, nil
+	err = ret.Validate()
+	assert.Equal(t, utils.CodeMissingChoice, Localize(err, English).Code)
+	assert.Equal(t, "One of Box C — Address is required", Localize(err, English).Text)
+}
+
+func TestOtherMessages(t *testing.T) {
+	ret := loadReturn(t)
+	ret.ReturnHeader.Filer.EIN = "001234567"
+	err := ret.Validate()
+	assert.True(t, errors.Is(err, utils.ErrTestIdentifier))
+	message := Localize(err, English)
+	assert.Equal(t, utils.CodeTestIdentifier, message.Code)
+	assert.Equal(t, "001234567", message.Value)
+
+	ret = loadReturn(t)
+	ret.ReturnHeader.TaxYr = irs_990.YearType(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	err = ret.ValidateDates()
+	assert.True(t, errors.Is(err, irs_990.ErrMismatchedTaxYr))
+	message = Localize(err, Spanish)
+	assert.Equal(t, "MismatchedTaxYr", message.Code)
+	assert.Equal(t, "El año tributario debe ser el año en que comienza el período tributario", message.Text)
+	assert.Equal(t, err.Error(), message.Detail)
+	assert.Equal(t, "The tax year must be the year the tax period begins", Localize(err, English).Text)
+
+	detail := errors.New("unexpected zip file")
+	assert.Equal(t, Message{Code: CodeOther, Text: detail.Error(), Detail: detail.Error()}, Localize(detail, Spanish))
+	assert.Equal(t, Message{}, Localize(nil, English))
+
+	// unknown languages fall back to English
+	assert.Equal(t, "The return has no e-file signature", Localize(irs_990.ErrMissingSignature, "fr").Text)
+}
@@ -99,6 +99,7 @@ type FormDataCreator struct {
 	SubmissionVersion string
 	SubmissionYear    int
 	SubmissionType    string
+	StylesheetDir     string
 	Header            interface{}
 	Data              []utils.ReturnInspectData
 }
@@ -116,7 +117,7 @@ func (r *FormDataCreator) GenerateXMLDocument(params *XMLParameters) []XMLDocume
 		formData.Parameters.SubmissionType = r.SubmissionType
 		formData.SubmissionHeaderAndDocument.ReturnHeader = r.Header
 		formData.SubmissionHeaderAndDocument.SubmissionDocument = returnData
-		data = append(data, XMLDocument{XML: []byte(formData.String()), Type: returnData.DataType, Year: r.SubmissionYear})
+		data = append(data, XMLDocument{XML: []byte(formData.String()), Type: returnData.DataType, Year: r.SubmissionYear, StylesheetDir: r.StylesheetDir})
 	}
 	return data
 }
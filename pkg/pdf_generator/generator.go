@@ -122,7 +122,11 @@ func getXSLFileName(formCode string) string {
 }
 
 func getStylesheetFile(document XMLDocument) (*string, error) {
-	filePath := filepath.Join(pathStylesheets, strconv.Itoa(document.Year), getXSLFileName(document.Type))
+	dir := document.StylesheetDir
+	if len(dir) == 0 {
+		dir = strconv.Itoa(document.Year)
+	}
+	filePath := filepath.Join(pathStylesheets, dir, getXSLFileName(document.Type))
 	_, err := os.Stat(filePath)
 	if os.IsNotExist(err) {
 		return nil, err
@@ -20,6 +20,8 @@ type XMLDocument struct {
 	XML  []byte
 	Type string
 	Year int
+	// Stylesheet directory of the return version, the year is used when empty
+	StylesheetDir string
 }
 
 // General return data interface
@@ -75,6 +77,7 @@ func CreateReturnForm(instance utils.Return) (ReturnFormData, error) {
 		Data:              info.Data,
 		SubmissionYear:    instance.ReturnYear(),
 		SubmissionVersion: instance.ReturnVersion(),
+		StylesheetDir:     instance.StylesheetDir(),
 		SubmissionType:    instance.ReturnType(),
 	}
 	return returnData, nil
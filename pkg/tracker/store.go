@@ -0,0 +1,340 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package tracker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+var (
+	// ErrInvalidTableName is given when the table name of a sql store isn't a plain identifier
+	ErrInvalidTableName = errors.New("invalid table name")
+)
+
+// submissionIdLen is the length of the submission ids, the file names of a file store
+var submissionIdLen = 20
+
+func sortSubmissions(submissions []Submission) {
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].SubmissionId < submissions[j].SubmissionId
+	})
+}
+
+// MemoryStore is a store in memory
+type MemoryStore struct {
+	mu          sync.RWMutex
+	submissions map[irs_990.SubmissionIdType]Submission
+}
+
+// NewMemoryStore returns an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{submissions: map[irs_990.SubmissionIdType]Submission{}}
+}
+
+// Save creates or replaces the submission
+func (s *MemoryStore) Save(submission Submission) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	submission.History = append([]Transition(nil), submission.History...)
+	s.submissions[submission.SubmissionId] = submission
+	return nil
+}
+
+// Get returns the submission of the submission id, nil when there isn't one
+func (s *MemoryStore) Get(id irs_990.SubmissionIdType) (*Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	submission, ok := s.submissions[id]
+	if !ok {
+		return nil, nil
+	}
+	submission.History = append([]Transition(nil), submission.History...)
+	return &submission, nil
+}
+
+// Query returns the submissions selected by the query ordered by submission id
+func (s *MemoryStore) Query(query Query) ([]Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var submissions []Submission
+	for _, submission := range s.submissions {
+		if query.Match(submission) {
+			submission.History = append([]Transition(nil), submission.History...)
+			submissions = append(submissions, submission)
+		}
+	}
+	sortSubmissions(submissions)
+	return submissions, nil
+}
+
+// FileStore is a store in a directory with a json file per submission
+type FileStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFileStore returns the store of the directory, the directory is created when missing
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(id irs_990.SubmissionIdType) (string, error) {
+	if err := id.Validate(); err != nil {
+		return "", err
+	}
+	if len(id) != submissionIdLen {
+		return "", fmt.Errorf("invalid submission id: %s", id)
+	}
+	return filepath.Join(s.dir, string(id)+".json"), nil
+}
+
+// Save creates or replaces the submission
+func (s *FileStore) Save(submission Submission) error {
+	path, err := s.path(submission.SubmissionId)
+	if err != nil {
+		return err
+	}
+	buf, err := json.MarshalIndent(submission, "", "  ")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return utils.WriteFileAtomic(path, buf)
+}
+
+// Get returns the submission of the submission id, nil when there isn't one
+func (s *FileStore) Get(id irs_990.SubmissionIdType) (*Submission, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return readSubmission(path)
+}
+
+// Query returns the submissions selected by the query ordered by submission id
+func (s *FileStore) Query(query Query) ([]Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var submissions []Submission
+	for _, path := range paths {
+		submission, err := readSubmission(path)
+		if err != nil {
+			return nil, err
+		}
+		if submission != nil && query.Match(*submission) {
+			submissions = append(submissions, *submission)
+		}
+	}
+	sortSubmissions(submissions)
+	return submissions, nil
+}
+
+func readSubmission(path string) (*Submission, error) {
+	buf, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var submission Submission
+	if err = json.Unmarshal(buf, &submission); err != nil {
+		return nil, fmt.Errorf("submission file %s: %w", path, err)
+	}
+	return &submission, nil
+}
+
+var tableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// DefaultTable is the table of a sql store without table name
+var DefaultTable = "submissions"
+
+// SQLStore is a store in a sql table. The searched fields have their own columns,
+// the history is kept as json. The driver is chosen by the caller, the driver must scan
+// TIMESTAMP columns into time.Time:
+//
+//	SQLite      modernc.org/sqlite, the driver of the tests
+//	PostgreSQL  github.com/lib/pq or github.com/jackc/pgx, with DollarPlaceholders
+//	MySQL       github.com/go-sql-driver/mysql, with parseTime=true in the data source name
+type SQLStore struct {
+	DB    *sql.DB
+	Table string
+	// DollarPlaceholders writes $1, $2 placeholders (PostgreSQL) instead of ? placeholders
+	DollarPlaceholders bool
+}
+
+// NewSQLStore returns the store of the table, DefaultTable is used without table name
+func NewSQLStore(db *sql.DB, table string) (*SQLStore, error) {
+	if len(table) == 0 {
+		table = DefaultTable
+	}
+	if !tableNameRegex.MatchString(table) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTableName, table)
+	}
+	return &SQLStore{DB: db, Table: table}, nil
+}
+
+// CreateTable creates the table when it doesn't exist
+func (s *SQLStore) CreateTable(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+s.Table+` (
+	submission_id VARCHAR(20) PRIMARY KEY,
+	ein VARCHAR(9) NOT NULL,
+	tax_yr INTEGER NOT NULL,
+	return_type_cd VARCHAR(10) NOT NULL,
+	state VARCHAR(20) NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	history TEXT NOT NULL
+)`)
+	return err
+}
+
+// bind replaces the ? placeholders of the statement for the driver
+func (s *SQLStore) bind(statement string) string {
+	if !s.DollarPlaceholders {
+		return statement
+	}
+	var builder strings.Builder
+	num := 0
+	for _, c := range statement {
+		if c == '?' {
+			num++
+			builder.WriteString("$" + strconv.Itoa(num))
+			continue
+		}
+		builder.WriteRune(c)
+	}
+	return builder.String()
+}
+
+// Save creates or replaces the submission
+func (s *SQLStore) Save(submission Submission) error {
+	history, err := json.Marshal(submission.History)
+	if err != nil {
+		return err
+	}
+	updated := submission.UpdatedAt.UTC()
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	result, err := tx.Exec(s.bind(`UPDATE `+s.Table+` SET ein = ?, tax_yr = ?, return_type_cd = ?, state = ?, updated_at = ?, history = ? WHERE submission_id = ?`),
+		string(submission.EIN), submission.TaxYr, string(submission.ReturnTypeCd), string(submission.State), updated, string(history), string(submission.SubmissionId))
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		_, err = tx.Exec(s.bind(`INSERT INTO `+s.Table+` (submission_id, ein, tax_yr, return_type_cd, state, updated_at, history) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+			string(submission.SubmissionId), string(submission.EIN), submission.TaxYr, string(submission.ReturnTypeCd), string(submission.State), updated, string(history))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Get returns the submission of the submission id, nil when there isn't one
+func (s *SQLStore) Get(id irs_990.SubmissionIdType) (*Submission, error) {
+	submissions, err := s.query(`WHERE submission_id = ?`, string(id))
+	if err != nil || len(submissions) == 0 {
+		return nil, err
+	}
+	return &submissions[0], nil
+}
+
+// Query returns the submissions selected by the query ordered by submission id
+func (s *SQLStore) Query(query Query) ([]Submission, error) {
+	where, args := queryConditions(query)
+	return s.query(where, args...)
+}
+
+// queryConditions returns the where clause of the query with its arguments
+func queryConditions(query Query) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if len(query.EIN) > 0 {
+		conditions = append(conditions, "ein = ?")
+		args = append(args, string(query.EIN))
+	}
+	if query.TaxYr > 0 {
+		conditions = append(conditions, "tax_yr = ?")
+		args = append(args, query.TaxYr)
+	}
+	if len(query.State) > 0 {
+		conditions = append(conditions, "state = ?")
+		args = append(args, string(query.State))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (s *SQLStore) query(where string, args ...interface{}) ([]Submission, error) {
+	statement := `SELECT submission_id, ein, tax_yr, return_type_cd, state, updated_at, history FROM ` + s.Table
+	if len(where) > 0 {
+		statement += " " + where
+	}
+	statement += " ORDER BY submission_id"
+
+	rows, err := s.DB.Query(s.bind(statement), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var submissions []Submission
+	for rows.Next() {
+		var id, ein, returnType, state, history string
+		var year int
+		var updated time.Time
+		if err = rows.Scan(&id, &ein, &year, &returnType, &state, &updated, &history); err != nil {
+			return nil, err
+		}
+		submission := Submission{
+			SubmissionId: irs_990.SubmissionIdType(id),
+			EIN:          irs_990.EINType(ein),
+			TaxYr:        year,
+			ReturnTypeCd: irs_990.ReturnTypeCd(returnType),
+			State:        State(state),
+			UpdatedAt:    updated,
+		}
+		if err = json.Unmarshal([]byte(history), &submission.History); err != nil {
+			return nil, fmt.Errorf("submission %s: %w", id, err)
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, rows.Err()
+}
@@ -0,0 +1,339 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+)
+
+var (
+	// ErrUnknownSubmission is given when the tracker has no submission of the submission id
+	ErrUnknownSubmission = errors.New("submission isn't tracked")
+	// ErrDuplicateSubmission is given when a submission id is tracked already
+	ErrDuplicateSubmission = errors.New("submission is tracked already")
+	// ErrMissingSubmissionId is given when a file hasn't a manifest with a submission id
+	ErrMissingSubmissionId = errors.New("file hasn't a manifest with a submission id")
+	// ErrInvalidTransition is given when a submission can't move from its state to the new state
+	ErrInvalidTransition = errors.New("invalid state transition")
+	// ErrUnknownStatus is given when a status record or an acknowledgement has an unknown status text
+	ErrUnknownStatus = errors.New("unknown status text")
+)
+
+// State is the lifecycle state of a submission
+type State string
+
+const (
+	// StateCreated is a built submission
+	StateCreated State = "created"
+	// StateValidated is a submission without blocking validation issues
+	StateValidated State = "validated"
+	// StatePackaged is a submission written into its submission zip
+	StatePackaged State = "packaged"
+	// StateTransmitted is a submission sent to MeF
+	StateTransmitted State = "transmitted"
+	// StateReceived is a submission with a receipt of MeF
+	StateReceived State = "received"
+	// StateAccepted is a submission accepted by the IRS
+	StateAccepted State = "accepted"
+	// StateRejected is a submission rejected by the IRS
+	StateRejected State = "rejected"
+	// StateDeniedByState is a submission denied by the state agency
+	StateDeniedByState State = "denied-by-state"
+)
+
+// states are the states in lifecycle order
+var states = []State{
+	StateCreated, StateValidated, StatePackaged, StateTransmitted,
+	StateReceived, StateAccepted, StateRejected, StateDeniedByState,
+}
+
+func (s State) order() int {
+	for index, state := range states {
+		if state == s {
+			return index
+		}
+	}
+	return -1
+}
+
+// IsFinal reports whether no further transition is expected
+func (s State) IsFinal() bool {
+	return s == StateRejected || s == StateDeniedByState
+}
+
+// CanTransition reports whether a submission can move from the state to the next state.
+// States only move forward, steps may be skipped, and an accepted submission can still be
+// denied by the state agency. Rejected and denied submissions don't move anymore.
+func (s State) CanTransition(next State) bool {
+	if s.order() < 0 || next.order() < 0 || s.IsFinal() {
+		return false
+	}
+	if s == StateAccepted {
+		return next == StateDeniedByState
+	}
+	return next.order() > s.order()
+}
+
+// Status texts of the status records, the texts of a submission being processed by MeF
+// or by a state agency keep a received submission
+var statusStates = map[string]State{
+	"received":                            StateReceived,
+	"ready for pickup":                    StateReceived,
+	"ready for pickup by state":           StateReceived,
+	"sent to state":                       StateReceived,
+	"received by state":                   StateReceived,
+	"acknowledgement received from state": StateReceived,
+	"acknowledgement retrieved":           StateReceived,
+	"accepted acknowledgement created":    StateAccepted,
+	"rejected acknowledgement created":    StateRejected,
+	"denied by irs":                       StateRejected,
+	"denied by state":                     StateDeniedByState,
+}
+
+// Acceptance status texts of the acknowledgements of the IRS and of the state agencies
+var ackStates = map[string]State{
+	"accepted": StateAccepted,
+	"rejected": StateRejected,
+}
+
+// statusKey returns the key of a status text in statusStates and ackStates, the texts are matched
+// without regard to case and surrounding spaces
+func statusKey(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
+
+// Transition is a state change of a submission
+type Transition struct {
+	From   State     `json:",omitempty"`
+	To     State     `json:"To"`
+	At     time.Time `json:"At"`
+	Reason string    `json:",omitempty"`
+}
+
+// Submission is the tracked lifecycle of a submission
+type Submission struct {
+	SubmissionId irs_990.SubmissionIdType `json:"SubmissionId"`
+	EIN          irs_990.EINType          `json:"EIN"`
+	TaxYr        int                      `json:"TaxYr"`
+	ReturnTypeCd irs_990.ReturnTypeCd     `json:"ReturnTypeCd"`
+	State        State                    `json:"State"`
+	UpdatedAt    time.Time                `json:"UpdatedAt"`
+	History      []Transition             `json:"History"`
+}
+
+// Query selects tracked submissions, zero values match every submission
+type Query struct {
+	EIN   irs_990.EINType
+	TaxYr int
+	State State
+}
+
+// Match reports whether the submission is selected by the query
+func (q Query) Match(submission Submission) bool {
+	if len(q.EIN) > 0 && q.EIN != submission.EIN {
+		return false
+	}
+	if q.TaxYr > 0 && q.TaxYr != submission.TaxYr {
+		return false
+	}
+	if len(q.State) > 0 && q.State != submission.State {
+		return false
+	}
+	return true
+}
+
+// Store persists the tracked submissions
+type Store interface {
+	// Save creates or replaces the submission
+	Save(submission Submission) error
+	// Get returns the submission of the submission id, nil when there isn't one
+	Get(id irs_990.SubmissionIdType) (*Submission, error)
+	// Query returns the submissions selected by the query ordered by submission id
+	Query(query Query) ([]Submission, error)
+}
+
+// Tracker records the lifecycle of submissions: created, validated, packaged, transmitted,
+// received and finally accepted, rejected or denied by the state agency
+type Tracker struct {
+	Store Store
+	// Now returns the time of the transitions, time.Now when nil
+	Now func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewTracker returns a tracker of the store, a memory store is used without store
+func NewTracker(store Store) *Tracker {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	return &Tracker{Store: store}
+}
+
+func (t *Tracker) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
+
+// Create starts tracking the submission of the file in the created state
+func (t *Tracker) Create(file *irs_990.Irs990File) (*Submission, error) {
+	if file == nil || file.Manifest == nil || len(file.Manifest.SubmissionId) == 0 {
+		return nil, ErrMissingSubmissionId
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := file.Manifest.SubmissionId
+	previous, err := t.Store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateSubmission, id)
+	}
+
+	header := file.XmlData.ReturnHeader
+	now := t.now()
+	submission := Submission{
+		SubmissionId: id,
+		EIN:          header.Filer.EIN,
+		TaxYr:        time.Time(header.TaxYr).Year(),
+		ReturnTypeCd: header.ReturnTypeCd,
+		State:        StateCreated,
+		UpdatedAt:    now,
+		History:      []Transition{{To: StateCreated, At: now}},
+	}
+	if err = t.Store.Save(submission); err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// Transition moves the submission to the next state, a transition to the current state does nothing
+func (t *Tracker) Transition(id irs_990.SubmissionIdType, next State, reason string) (*Submission, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.transition(id, next, reason, false)
+}
+
+// transition moves the submission, stale transitions are skipped when lenient
+func (t *Tracker) transition(id irs_990.SubmissionIdType, next State, reason string, lenient bool) (*Submission, error) {
+	submission, err := t.Store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSubmission, id)
+	}
+	if submission.State == next {
+		return submission, nil
+	}
+	if !submission.State.CanTransition(next) {
+		if lenient {
+			return submission, nil
+		}
+		return nil, fmt.Errorf("%w: %s from %s to %s", ErrInvalidTransition, id, submission.State, next)
+	}
+
+	now := t.now()
+	submission.History = append(submission.History, Transition{From: submission.State, To: next, At: now, Reason: reason})
+	submission.State = next
+	submission.UpdatedAt = now
+	if err = t.Store.Save(*submission); err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// Validated marks the submission as validated
+func (t *Tracker) Validated(id irs_990.SubmissionIdType) (*Submission, error) {
+	return t.Transition(id, StateValidated, "")
+}
+
+// Packaged marks the submission as packaged into its submission zip
+func (t *Tracker) Packaged(id irs_990.SubmissionIdType) (*Submission, error) {
+	return t.Transition(id, StatePackaged, "")
+}
+
+// Transmitted marks the submission as sent to MeF
+func (t *Tracker) Transmitted(id irs_990.SubmissionIdType) (*Submission, error) {
+	return t.Transition(id, StateTransmitted, "")
+}
+
+// The receipts, status records and acknowledgements of MeF can arrive out of order,
+// their transitions are skipped when the submission is in a later state already.
+
+// Receipt applies a submission receipt of MeF
+func (t *Tracker) Receipt(grp irs_990.SubmissionReceiptGrp) (*Submission, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.transition(grp.SubmissionId, StateReceived, "receipt", true)
+}
+
+// Status applies a status record of MeF, unknown status texts are an error
+func (t *Tracker) Status(grp irs_990.StatusRecordGrp) (*Submission, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	next, ok := statusStates[statusKey(grp.SubmissionStatusTxt)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s of %s", ErrUnknownStatus, grp.SubmissionStatusTxt, grp.SubmissionId)
+	}
+	return t.transition(grp.SubmissionId, next, "status: "+grp.SubmissionStatusTxt, true)
+}
+
+// Acknowledge applies an acknowledgement of the IRS or of a state agency, unknown acceptance
+// status texts are an error
+func (t *Tracker) Acknowledge(ack irs_990.Acknowledgement) (*Submission, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	next, ok := ackStates[statusKey(ack.AcceptanceStatusTxt)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s of %s", ErrUnknownStatus, ack.AcceptanceStatusTxt, ack.SubmissionId)
+	}
+	return t.transition(ack.SubmissionId, next, "acknowledgement: "+ack.AcceptanceStatusTxt, true)
+}
+
+// Get returns the submission of the submission id
+func (t *Tracker) Get(id irs_990.SubmissionIdType) (*Submission, error) {
+	submission, err := t.Store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSubmission, id)
+	}
+	return submission, nil
+}
+
+// ByEIN returns the submissions of the EIN
+func (t *Tracker) ByEIN(ein irs_990.EINType) ([]Submission, error) {
+	return t.Store.Query(Query{EIN: ein})
+}
+
+// ByTaxYr returns the submissions of the tax year
+func (t *Tracker) ByTaxYr(year int) ([]Submission, error) {
+	return t.Store.Query(Query{TaxYr: year})
+}
+
+// ByState returns the submissions in the state
+func (t *Tracker) ByState(state State) ([]Submission, error) {
+	return t.Store.Query(Query{State: state})
+}
+
+// Query returns the submissions selected by the query
+func (t *Tracker) Query(query Query) ([]Submission, error) {
+	return t.Store.Query(query)
+}
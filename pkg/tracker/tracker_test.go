@@ -0,0 +1,181 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package tracker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+
+	"github.com/moov-io/1120x/pkg/irs_990"
+	"github.com/moov-io/1120x/pkg/utils"
+)
+
+func testFile(t *testing.T) *irs_990.Irs990File {
+	returnBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_return.xml"))
+	assert.Equal(t, nil, err)
+	manifestBuf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "irs990_submission_manifest.xml"))
+	assert.Equal(t, nil, err)
+
+	file := &irs_990.Irs990File{Manifest: &irs_990.IRSSubmissionManifest{}, TestCd: utils.TestCd}
+	assert.Equal(t, nil, xml.Unmarshal(returnBuf, &file.XmlData))
+	assert.Equal(t, nil, xml.Unmarshal(manifestBuf, file.Manifest))
+	return file
+}
+
+func TestTracker(t *testing.T) {
+	fileStore, err := NewFileStore(filepath.Join(t.TempDir(), "submissions"))
+	assert.Equal(t, nil, err)
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "submissions.db"))
+	assert.Equal(t, nil, err)
+	defer db.Close()
+	sqlStore, err := NewSQLStore(db, "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, sqlStore.CreateTable(context.Background()))
+
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+		"sql":    sqlStore,
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			testTracker(t, store)
+		})
+	}
+}
+
+func testTracker(t *testing.T, store Store) {
+	now := time.Date(2020, 11, 2, 9, 30, 0, 0, time.UTC)
+	tracker := NewTracker(store)
+	tracker.Now = func() time.Time { return now }
+
+	file := testFile(t)
+	id := file.Manifest.SubmissionId
+	ein := file.XmlData.ReturnHeader.Filer.EIN
+	year := time.Time(file.XmlData.ReturnHeader.TaxYr).Year()
+
+	_, err := tracker.Create(&irs_990.Irs990File{})
+	assert.True(t, errors.Is(err, ErrMissingSubmissionId))
+
+	submission, err := tracker.Create(file)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateCreated, submission.State)
+	assert.Equal(t, ein, submission.EIN)
+	_, err = tracker.Create(file)
+	assert.True(t, errors.Is(err, ErrDuplicateSubmission))
+
+	_, err = tracker.Validated(id)
+	assert.Equal(t, nil, err)
+	_, err = tracker.Packaged(id)
+	assert.Equal(t, nil, err)
+	_, err = tracker.Transmitted(id)
+	assert.Equal(t, nil, err)
+	_, err = tracker.Validated(id)
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	_, err = tracker.Validated("0000000000111unknown")
+	assert.True(t, errors.Is(err, ErrUnknownSubmission))
+
+	submission, err = tracker.Receipt(irs_990.SubmissionReceiptGrp{SubmissionId: id})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateReceived, submission.State)
+
+	// status texts of a submission being processed keep it received, unknown texts are an error
+	submission, err = tracker.Status(irs_990.StatusRecordGrp{SubmissionId: id, SubmissionStatusTxt: "Acknowledgement Retrieved"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateReceived, submission.State)
+	_, err = tracker.Status(irs_990.StatusRecordGrp{SubmissionId: id, SubmissionStatusTxt: "Accepted"})
+	assert.True(t, errors.Is(err, ErrUnknownStatus))
+	submission, err = tracker.Get(id)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateReceived, submission.State)
+
+	submission, err = tracker.Acknowledge(irs_990.Acknowledgement{SubmissionId: id, AcceptanceStatusTxt: irs_990.AcceptedStatus})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateAccepted, submission.State)
+
+	// late receipts are skipped
+	submission, err = tracker.Receipt(irs_990.SubmissionReceiptGrp{SubmissionId: id})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateAccepted, submission.State)
+
+	submission, err = tracker.Status(irs_990.StatusRecordGrp{SubmissionId: id, SubmissionStatusTxt: "Denied by State"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateDeniedByState, submission.State)
+
+	submission, err = tracker.Get(id)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateDeniedByState, submission.State)
+	assert.Equal(t, 7, len(submission.History))
+	assert.Equal(t, StateAccepted, submission.History[6].From)
+	assert.True(t, now.Equal(submission.UpdatedAt))
+
+	// a second submission of the EIN is rejected
+	other := testFile(t)
+	other.Manifest.SubmissionId = "00000020203070000001"
+	_, err = tracker.Create(other)
+	assert.Equal(t, nil, err)
+	_, err = tracker.Transmitted(other.Manifest.SubmissionId)
+	assert.Equal(t, nil, err)
+	// acceptance status texts are matched like the status texts, the texts of the status records are unknown
+	_, err = tracker.Acknowledge(irs_990.Acknowledgement{SubmissionId: other.Manifest.SubmissionId, AcceptanceStatusTxt: "Pending state review"})
+	assert.True(t, errors.Is(err, ErrUnknownStatus))
+	_, err = tracker.Acknowledge(irs_990.Acknowledgement{SubmissionId: other.Manifest.SubmissionId, AcceptanceStatusTxt: irs_990.DeniedByStateStatus})
+	assert.True(t, errors.Is(err, ErrUnknownStatus))
+	submission, err = tracker.Get(other.Manifest.SubmissionId)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateTransmitted, submission.State)
+	submission, err = tracker.Acknowledge(irs_990.Acknowledgement{SubmissionId: other.Manifest.SubmissionId, AcceptanceStatusTxt: " rejected "})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StateRejected, submission.State)
+
+	submissions, err := tracker.ByEIN(ein)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(submissions))
+	assert.Equal(t, id, submissions[0].SubmissionId)
+
+	submissions, err = tracker.ByTaxYr(year)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(submissions))
+	submissions, err = tracker.ByTaxYr(year + 1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(submissions))
+
+	submissions, err = tracker.ByState(StateRejected)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(submissions))
+	assert.Equal(t, other.Manifest.SubmissionId, submissions[0].SubmissionId)
+
+	submissions, err = tracker.Query(Query{EIN: ein, TaxYr: year, State: StateDeniedByState})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(submissions))
+}
+
+func TestStateTransitions(t *testing.T) {
+	assert.True(t, StateCreated.CanTransition(StateTransmitted))
+	assert.True(t, StateReceived.CanTransition(StateRejected))
+	assert.True(t, StateAccepted.CanTransition(StateDeniedByState))
+	assert.False(t, StateAccepted.CanTransition(StateRejected))
+	assert.False(t, StateRejected.CanTransition(StateAccepted))
+	assert.False(t, StateTransmitted.CanTransition(StatePackaged))
+	assert.False(t, StateCreated.CanTransition("unknown"))
+
+	_, err := NewSQLStore(nil, "submissions; DROP TABLE x")
+	assert.True(t, errors.Is(err, ErrInvalidTableName))
+
+	store := &SQLStore{DollarPlaceholders: true}
+	where, args := queryConditions(Query{EIN: "201585919", State: StateAccepted})
+	assert.Equal(t, "WHERE ein = $1 AND state = $2", store.bind(where))
+	assert.Equal(t, 2, len(args))
+}
@@ -65,42 +65,128 @@ func validateCallbackByValue(data reflect.Value) error {
 }
 
 // to validate interface
+//
+// Missing required elements are reported, empty optional elements are skipped
 func Validate(r interface{}) error {
 	var err error
 
 	fields := reflect.ValueOf(r).Elem()
+	fieldsType := fields.Type()
 	for i := 0; i < fields.NumField(); i++ {
 		fieldData := fields.Field(i)
 
+		if IsMissingValue(fieldData) {
+			if IsRequiredField(fieldsType.Field(i)) {
+				return missingElementError(fieldsType, fieldsType.Field(i))
+			}
+			if ElementName(fieldsType.Field(i)) != "" {
+				continue
+			}
+		}
+
 		kind := fieldData.Kind()
 		if kind == reflect.Slice {
-			for i := 0; i < fieldData.Len(); i++ {
-				err = validateCallbackByValue(fieldData.Index(i))
+			for j := 0; j < fieldData.Len(); j++ {
+				err = validateCallbackByValue(fieldData.Index(j))
 				if err != nil {
-					return err
+					return fieldError(fieldsType, fieldsType.Field(i), fieldData.Index(j), err)
 				}
 			}
 		} else if kind == reflect.Map {
 			for _, key := range fieldData.MapKeys() {
 				err = validateCallbackByValue(fieldData.MapIndex(key))
 				if err != nil {
-					return err
+					return fieldError(fieldsType, fieldsType.Field(i), fieldData.MapIndex(key), err)
 				}
 			}
 		} else if kind == reflect.Ptr {
 			if fieldData.Pointer() != 0 {
 				err = validateCallbackByValue(fieldData)
 				if err != nil {
-					return err
+					return fieldError(fieldsType, fieldsType.Field(i), fieldData, err)
 				}
 			}
 		} else {
 			err = validateCallbackByValue(fieldData)
 			if err != nil {
-				return err
+				return fieldError(fieldsType, fieldsType.Field(i), fieldData, err)
+			}
+		}
+	}
+
+	return validateChoices(fields)
+}
+
+// ValidateAll validates r like Validate but doesn't stop at the first error, it returns an error per
+// invalid element. The elements of nested structs are validated one by one instead of by their Validate
+// methods, so it only fits structs whose nested structs are validated by Validate alone.
+func ValidateAll(r interface{}) []error {
+	return validateAll(reflect.ValueOf(r).Elem())
+}
+
+func validateAll(fields reflect.Value) []error {
+	var errs []error
+
+	fieldsType := fields.Type()
+	for i := 0; i < fields.NumField(); i++ {
+		field := fieldsType.Field(i)
+		fieldData := fields.Field(i)
+
+		if IsMissingValue(fieldData) {
+			if IsRequiredField(field) {
+				errs = append(errs, missingElementError(fieldsType, field))
+				continue
+			}
+			if ElementName(field) != "" {
+				continue
 			}
 		}
+
+		switch fieldData.Kind() {
+		case reflect.Slice:
+			for j := 0; j < fieldData.Len(); j++ {
+				errs = append(errs, validateElement(fieldsType, field, fieldData.Index(j))...)
+			}
+		case reflect.Map:
+			for _, key := range fieldData.MapKeys() {
+				errs = append(errs, validateElement(fieldsType, field, fieldData.MapIndex(key))...)
+			}
+		case reflect.Ptr:
+			if fieldData.Pointer() != 0 {
+				errs = append(errs, validateElement(fieldsType, field, fieldData)...)
+			}
+		default:
+			errs = append(errs, validateElement(fieldsType, field, fieldData)...)
+		}
 	}
 
+	return append(errs, choiceErrors(fields)...)
+}
+
+// validateElement validates an element of ValidateAll, a struct with elements is validated element by element
+func validateElement(parent reflect.Type, field reflect.StructField, data reflect.Value) []error {
+	elem := data
+	for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	if hasElements(elem.Type()) {
+		return validateAll(elem)
+	}
+	if err := validateCallbackByValue(data); err != nil {
+		return []error{fieldError(parent, field, data, err)}
+	}
 	return nil
 }
+
+// hasElements reports whether typ is a struct of xml elements, unlike the simple types based on time.Time
+func hasElements(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if _, ok := typ.Field(i).Tag.Lookup(xmlTagName); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Error codes of validation errors
+const (
+	CodeMissingElement     = "MissingElement"
+	CodeMissingChoice      = "MissingChoice"
+	CodeMultipleChoice     = "MultipleChoice"
+	CodeInvalidValue       = "InvalidValue"
+	CodeUnsupportedElement = "UnsupportedElement"
+	CodeTestIdentifier     = "TestIdentifier"
+)
+
+// FieldError is a validation error of an element with its error code, the element and the offending value
+type FieldError struct {
+	Code string
+	// Parent is the type holding the element
+	Parent string
+	// Element is the xml element name, members of a choice are joined by "|"
+	Element string
+	// Type is the type of the element value
+	Type  string
+	Value string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	switch e.Code {
+	case CodeMissingElement:
+		return fmt.Sprintf("%s.%s: %v", e.Parent, e.Element, e.Err)
+	case CodeMissingChoice, CodeMultipleChoice:
+		return fmt.Sprintf("%s.(%s): %v", e.Parent, e.Element, e.Err)
+	}
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError wraps the error of a field value, errors of nested elements are kept as they are
+func fieldError(parent reflect.Type, field reflect.StructField, data reflect.Value, err error) error {
+	return withFieldError(CodeInvalidValue, parent, field, data, err)
+}
+
+func withFieldError(code string, parent reflect.Type, field reflect.StructField, data reflect.Value, err error) error {
+	var nested *FieldError
+	if errors.As(err, &nested) {
+		return err
+	}
+
+	for data.Kind() == reflect.Ptr || data.Kind() == reflect.Interface {
+		if data.IsNil() {
+			break
+		}
+		data = data.Elem()
+	}
+
+	fieldErr := &FieldError{
+		Code:    code,
+		Parent:  parent.Name(),
+		Element: ElementName(field),
+		Type:    data.Type().Name(),
+		Err:     err,
+	}
+	switch data.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map, reflect.Array, reflect.Ptr, reflect.Interface:
+	default:
+		fieldErr.Value = fmt.Sprint(data.Interface())
+	}
+	return fieldErr
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ValidatorFunc is an extra validation rule attached to a type or to an element path.
+// The value is the element value, pointers are dereferenced and absent elements are skipped.
+type ValidatorFunc func(value interface{}) error
+
+var (
+	validatorsMu   sync.RWMutex
+	typeValidators = map[reflect.Type][]ValidatorFunc{}
+	pathValidators []pathValidator
+)
+
+type pathValidator struct {
+	xpath string
+	fn    ValidatorFunc
+}
+
+// RegisterTypeValidator attaches fn to every element of the type of sample, e.g. RegisterTypeValidator(irs_990.PersonTitleType(""), fn)
+func RegisterTypeValidator(sample interface{}, fn ValidatorFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	dataType := reflect.TypeOf(sample)
+	typeValidators[dataType] = append(typeValidators[dataType], fn)
+}
+
+// RegisterPathValidator attaches fn to the elements of an XPath.
+// An absolute path starts from the document, e.g. "/Return/ReturnHeader/BusinessOfficerGrp/PersonTitleTxt",
+// a relative path matches the end of the element path, e.g. "IRS990/WebsiteAddressTxt".
+// Attributes are given as "@name".
+func RegisterPathValidator(xpath string, fn ValidatorFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	pathValidators = append(pathValidators, pathValidator{xpath: xpath, fn: fn})
+}
+
+// ResetValidators removes all registered validators
+func ResetValidators() {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	typeValidators = map[reflect.Type][]ValidatorFunc{}
+	pathValidators = nil
+}
+
+// ValidateHooks runs the registered validators on the elements of the document r, root is the name of the document element.
+// The first failure is reported with the path of the element. The validators run without the
+// lock of the registry, so they may register validators.
+func ValidateHooks(r interface{}, root string) error {
+	validatorsMu.RLock()
+	empty := len(typeValidators) == 0 && len(pathValidators) == 0
+	validatorsMu.RUnlock()
+
+	if empty {
+		return nil
+	}
+	return validateHooks(reflect.ValueOf(r), "/"+root)
+}
+
+func validateHooks(data reflect.Value, path string) error {
+	switch data.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !data.IsNil() {
+			return validateHooks(data.Elem(), path)
+		}
+		return nil
+	case reflect.Slice:
+		if data.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < data.Len(); i++ {
+				if err := validateHooks(data.Index(i), path); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	if err := runValidators(data, path); err != nil {
+		return err
+	}
+
+	if data.Kind() != reflect.Struct || data.Type().ConvertibleTo(timeType) {
+		return nil
+	}
+	for i := 0; i < data.NumField(); i++ {
+		field := data.Type().Field(i)
+		name := ElementName(field)
+		if name == "" || !field.IsExported() || IsMissingValue(data.Field(i)) {
+			continue
+		}
+		if strings.Contains(field.Tag.Get(xmlTagName), ",attr") {
+			name = "@" + name
+		}
+		if err := validateHooks(data.Field(i), path+"/"+name); err != nil {
+			return withFieldError(CodeInvalidValue, data.Type(), field, data.Field(i), err)
+		}
+	}
+	return nil
+}
+
+func runValidators(data reflect.Value, path string) error {
+	if !data.CanInterface() {
+		return nil
+	}
+	value := data.Interface()
+
+	for _, fn := range matchingValidators(data.Type(), path) {
+		if err := fn(value); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// matchingValidators copies the validators of the type and of the path under the lock
+func matchingValidators(dataType reflect.Type, path string) []ValidatorFunc {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+
+	fns := append([]ValidatorFunc(nil), typeValidators[dataType]...)
+	for _, validator := range pathValidators {
+		if matchPath(validator.xpath, path) {
+			fns = append(fns, validator.fn)
+		}
+	}
+	return fns
+}
+
+func matchPath(xpath, path string) bool {
+	if strings.HasPrefix(xpath, "/") {
+		return xpath == path
+	}
+	return strings.HasSuffix(path, "/"+xpath)
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// TestCd is the test indicator of a test (ATS) transmission, the TestCd of the MeF header
+	TestCd = "T"
+	// ProductionCd is the test indicator of a production transmission
+	ProductionCd = "P"
+)
+
+var (
+	// ErrTestIdentifier is given when a test identifier is used by a production submission
+	ErrTestIdentifier = errors.New("test identifier isn't allowed in a production submission")
+)
+
+// TestIdentifier is implemented by identifier types that have values reserved for testing (ATS)
+type TestIdentifier interface {
+	IsTestIdentifier() bool
+}
+
+var testIdentifierType = reflect.TypeOf((*TestIdentifier)(nil)).Elem()
+
+// ValidateProductionIdentifiers reports the first test identifier of r
+func ValidateProductionIdentifiers(r interface{}) error {
+	return validateProductionIdentifiers(reflect.ValueOf(r))
+}
+
+func validateProductionIdentifiers(data reflect.Value) error {
+	if data.Kind() != reflect.Interface && data.Kind() != reflect.Ptr && data.Type().Implements(testIdentifierType) {
+		if id, ok := data.Interface().(TestIdentifier); ok && id.IsTestIdentifier() {
+			return fmt.Errorf("%w: %s %v", ErrTestIdentifier, data.Type().Name(), data.Interface())
+		}
+		return nil
+	}
+
+	switch data.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !data.IsNil() {
+			return validateProductionIdentifiers(data.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < data.Len(); i++ {
+			if err := validateProductionIdentifiers(data.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < data.NumField(); i++ {
+			if !data.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := validateProductionIdentifiers(data.Field(i)); err != nil {
+				return withFieldError(CodeTestIdentifier, data.Type(), data.Type().Field(i), data.Field(i), err)
+			}
+		}
+	}
+
+	return nil
+}
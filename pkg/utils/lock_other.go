@@ -0,0 +1,23 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+//go:build !unix && !windows
+
+package utils
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrFileLockUnsupported is given when files can't be locked on the platform
+var ErrFileLockUnsupported = errors.New("file locks aren't supported on this platform")
+
+func lockFile(file *os.File) error {
+	return ErrFileLockUnsupported
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
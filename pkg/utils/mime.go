@@ -0,0 +1,141 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+)
+
+// MIME parts of a transmission file
+var (
+	DefaultMIMEBoundary      = "MIMEBoundary"
+	EnvelopeLocation         = "Envelope"
+	EnvelopeContentID        = "<Envelope>"
+	AttachmentLocation       = "TransmissionAttachment"
+	eFileRoutingCode         = "MEF"
+	envelopeContentType      = "text/xml; charset=UTF-8"
+	attachmentContentType    = "application/octet-stream"
+	envelopeTransferEncoding = "8bit"
+	binaryTransferEncoding   = "binary"
+	gzipContentEncoding      = "gzip"
+)
+
+// TransmissionOptions are the options of a transmission file
+type TransmissionOptions struct {
+	// Boundary of the MIME parts, DefaultMIMEBoundary when empty
+	Boundary string
+	// Gzip compresses the SOAP attachment
+	Gzip bool
+}
+
+// TransmissionMessage builds the transmission file of the e-file: the MIME multi-part content
+// header, the SOAP envelope part, the SOAP attachment part and the end boundary
+func TransmissionMessage(file IrsTransmissionFile, options *TransmissionOptions) ([]byte, error) {
+	envelope, err := file.SOAPEnvelope()
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := file.SOAPAttachment()
+	if err != nil {
+		return nil, err
+	}
+	contentType, body, err := MultipartMessage(envelope, attachment, options)
+	if err != nil {
+		return nil, err
+	}
+
+	message := new(bytes.Buffer)
+	message.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(message, "Content-Type: %s\r\n", contentType)
+	fmt.Fprintf(message, "X-eFileRoutingCode: %s\r\n", eFileRoutingCode)
+	message.WriteString("\r\n")
+	message.Write(body)
+	return message.Bytes(), nil
+}
+
+// MultipartMessage builds the MIME multi-part parts of a SOAP envelope and attachment, without
+// the content header. It returns the content type of the parts, an empty attachment is left out.
+func MultipartMessage(envelope, attachment []byte, options *TransmissionOptions) (string, []byte, error) {
+	if options == nil {
+		options = &TransmissionOptions{}
+	}
+	boundary := options.Boundary
+	if len(boundary) == 0 {
+		boundary = DefaultMIMEBoundary
+	}
+
+	if !bytes.HasPrefix(envelope, []byte("<?xml")) {
+		envelope = append([]byte(xml.Header), envelope...)
+	}
+	var err error
+	if options.Gzip && len(attachment) > 0 {
+		if attachment, err = gzipData(attachment); err != nil {
+			return "", nil, err
+		}
+	}
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	if err = writer.SetBoundary(boundary); err != nil {
+		return "", nil, err
+	}
+
+	envelopeHeader := textproto.MIMEHeader{}
+	envelopeHeader.Set("Content-Type", envelopeContentType)
+	envelopeHeader.Set("Content-Transfer-Encoding", envelopeTransferEncoding)
+	envelopeHeader.Set("Content-Location", EnvelopeLocation)
+	envelopeHeader.Set("Content-ID", EnvelopeContentID)
+	part, err := writer.CreatePart(envelopeHeader)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err = part.Write(envelope); err != nil {
+		return "", nil, err
+	}
+
+	if len(attachment) > 0 {
+		attachmentHeader := textproto.MIMEHeader{}
+		attachmentHeader.Set("Content-Type", attachmentContentType)
+		attachmentHeader.Set("Content-Transfer-Encoding", binaryTransferEncoding)
+		attachmentHeader.Set("Content-Location", AttachmentLocation)
+		if options.Gzip {
+			attachmentHeader.Set("Content-Encoding", gzipContentEncoding)
+		}
+		part, err = writer.CreatePart(attachmentHeader)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err = part.Write(attachment); err != nil {
+			return "", nil, err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return "", nil, err
+	}
+	return TransmissionContentType(boundary), body.Bytes(), nil
+}
+
+// TransmissionContentType returns the content type of a transmission file with the boundary.
+// The start parameter is the Content-ID of the envelope, the root part of RFC 2387.
+func TransmissionContentType(boundary string) string {
+	return fmt.Sprintf(`multipart/related; boundary="%s"; type="text/xml"; start="%s"`, boundary, EnvelopeContentID)
+}
+
+func gzipData(data []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := gzip.NewWriter(buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//////////////////////////////////////////////
+//  Required-ness of generated elements     //
+//                                          //
+//  xml:"Name"            required          //
+//  xml:"Name,omitempty"  optional          //
+//  choice:"Group"        at least one of   //
+//                        the fields of the //
+//                        group is required //
+//  choice:"Group,        at most one of    //
+//  optional"             the fields of the //
+//                        group is present  //
+//  choiceIf:"Name"       the group is only //
+//                        required when the //
+//                        Name element is   //
+//                        present and true  //
+//////////////////////////////////////////////
+
+var (
+	// ErrMissingElement is given when a required element is missing
+	ErrMissingElement = errors.New("required element is missing")
+	// ErrMissingChoice is given when none of the elements of a required choice is present
+	ErrMissingChoice = errors.New("one of the choice elements is required")
+	// ErrMultipleChoice is given when more than one element of an optional choice is present
+	ErrMultipleChoice = errors.New("only one of the choice elements is allowed")
+)
+
+var (
+	xmlTagName    = "xml"
+	choiceTagName = "choice"
+	choiceIfTag   = "choiceIf"
+	omitEmptyOpt  = "omitempty"
+	optionalOpt   = "optional"
+)
+
+// ElementName returns the xml element (or attribute) name of a struct field,
+// empty when the field isn't mapped to an element
+func ElementName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup(xmlTagName)
+	if !ok || tag == "-" || field.Name == "XMLName" {
+		return ""
+	}
+	return strings.Split(tag, ",")[0]
+}
+
+// IsRequiredField reports whether the schema requires the element of a struct field.
+// Fields without omitempty are required, members of a choice never are on their own.
+func IsRequiredField(field reflect.StructField) bool {
+	if ElementName(field) == "" {
+		return false
+	}
+	if _, ok := field.Tag.Lookup(choiceTagName); ok {
+		return false
+	}
+	for _, opt := range strings.Split(field.Tag.Get(xmlTagName), ",")[1:] {
+		if opt == omitEmptyOpt {
+			return false
+		}
+	}
+	return true
+}
+
+// IsMissingValue reports whether a value would be absent from the xml document.
+// Numbers and booleans always carry a value, so they are never missing, neither
+// are structs holding them (amount groups, indicators with attributes).
+func IsMissingValue(data reflect.Value) bool {
+	switch data.Kind() {
+	case reflect.String:
+		return data.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return data.IsNil()
+	case reflect.Slice, reflect.Map:
+		return data.Len() == 0
+	case reflect.Struct:
+		if data.Type().ConvertibleTo(timeType) {
+			return data.IsZero()
+		}
+		return data.IsZero() && !hasValueField(data.Type())
+	}
+	return false
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// hasValueField reports whether a struct has a number or boolean field, directly or in a nested struct
+func hasValueField(dataType reflect.Type) bool {
+	for i := 0; i < dataType.NumField(); i++ {
+		fieldType := dataType.Field(i).Type
+		switch fieldType.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		case reflect.Struct:
+			if !fieldType.ConvertibleTo(timeType) && hasValueField(fieldType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func missingElementError(parent reflect.Type, field reflect.StructField) error {
+	return &FieldError{Code: CodeMissingElement, Parent: parent.Name(), Element: ElementName(field), Type: field.Type.Name(), Err: ErrMissingElement}
+}
+
+// validateChoices returns the first error of choiceErrors
+func validateChoices(fields reflect.Value) error {
+	if errs := choiceErrors(fields); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// choiceErrors checks that each required choice of fields has at least one present element
+// and that each optional choice has at most one. A choice nested in the branch of another one is
+// only required when that branch is taken.
+func choiceErrors(fields reflect.Value) []error {
+	var errs []error
+
+	var groups []string
+	present := map[string]bool{}
+	count := map[string]int{}
+	members := map[string][]string{}
+	optional := map[string]bool{}
+	conditions := map[string]string{}
+	elements := map[string]bool{}
+
+	fieldsType := fields.Type()
+	for i := 0; i < fields.NumField(); i++ {
+		field := fieldsType.Field(i)
+		missing := IsMissingValue(fields.Field(i))
+		// zero values are omitted from the document, so a false indicator or a zero amount isn't present
+		set := !missing && !fields.Field(i).IsZero()
+		if set {
+			elements[ElementName(field)] = true
+		}
+		tag, ok := field.Tag.Lookup(choiceTagName)
+		if !ok {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		group := opts[0]
+		if _, exist := members[group]; !exist {
+			groups = append(groups, group)
+		}
+		members[group] = append(members[group], ElementName(field))
+		for _, opt := range opts[1:] {
+			if opt == optionalOpt {
+				optional[group] = true
+			}
+		}
+		if condition, ok := field.Tag.Lookup(choiceIfTag); ok {
+			conditions[group] = condition
+		}
+		if !missing {
+			present[group] = true
+		}
+		if set {
+			count[group]++
+		}
+	}
+
+	for _, group := range groups {
+		element := strings.Join(members[group], "|")
+		if optional[group] {
+			if count[group] > 1 {
+				errs = append(errs, &FieldError{Code: CodeMultipleChoice, Parent: fieldsType.Name(), Element: element, Err: ErrMultipleChoice})
+			}
+			continue
+		}
+		if condition, ok := conditions[group]; ok && !elements[condition] {
+			continue
+		}
+		if !present[group] {
+			errs = append(errs, &FieldError{Code: CodeMissingChoice, Parent: fieldsType.Name(), Element: element, Err: ErrMissingChoice})
+		}
+	}
+	return errs
+}
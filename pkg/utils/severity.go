@@ -0,0 +1,115 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the severity of a validation issue, the values are the SeverityCd values of the acknowledgements
+type Severity string
+
+const (
+	// SeverityRejectAndStop stops the processing of the submission, it always blocks the submission
+	SeverityRejectAndStop Severity = "Reject and Stop"
+	// SeverityReject rejects the submission, it always blocks the submission
+	SeverityReject Severity = "Reject"
+	// SeverityAlert is an advisory issue, the submission is accepted
+	SeverityAlert Severity = "Alert"
+)
+
+// IsReject reports whether the severity rejects the submission
+func (s Severity) IsReject() bool {
+	return s == SeverityRejectAndStop || s == SeverityReject
+}
+
+// ValidationIssue is an issue found by validation, shaped like the validation groups of an acknowledgement
+type ValidationIssue struct {
+	Severity        Severity
+	DocumentId      string
+	XpathContentTxt string
+	RuleNum         string
+	FieldValueTxt   string
+	Err             error
+}
+
+func (r ValidationIssue) Error() string {
+	return fmt.Sprintf("%s: %v", r.Severity, r.Err)
+}
+
+func (r ValidationIssue) Unwrap() error {
+	return r.Err
+}
+
+// ValidationResult holds the issues of a validation, rejects and alerts
+type ValidationResult struct {
+	Issues []ValidationIssue
+}
+
+// Add adds an issue of the severity for err, nil errors are skipped
+func (r *ValidationResult) Add(severity Severity, err error) {
+	if err == nil {
+		return
+	}
+	r.Issues = append(r.Issues, ValidationIssue{Severity: severity, Err: err})
+}
+
+// Rejects returns the issues that reject the submission
+func (r ValidationResult) Rejects() []ValidationIssue {
+	var issues []ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Severity.IsReject() {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Alerts returns the advisory issues
+func (r ValidationResult) Alerts() []ValidationIssue {
+	var issues []ValidationIssue
+	for _, issue := range r.Issues {
+		if !issue.Severity.IsReject() {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Err returns the first issue that blocks the submission. Rejects always block,
+// the blocking severities, like SeverityAlert, add to them.
+func (r ValidationResult) Err(blocking ...Severity) error {
+	for _, issue := range r.Issues {
+		if issue.Severity.IsReject() {
+			return issue
+		}
+		for _, severity := range blocking {
+			if issue.Severity == severity {
+				return issue
+			}
+		}
+	}
+	return nil
+}
+
+func (r ValidationResult) String() string {
+	var lines []string
+	for _, issue := range r.Issues {
+		lines = append(lines, issue.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FileValidationResult returns the validation result of the return file. The error of
+// a file that doesn't implement ValidationResulter is a reject.
+func FileValidationResult(file IrsReturnFile) ValidationResult {
+	if resulter, ok := file.(ValidationResulter); ok {
+		return resulter.ValidationResult()
+	}
+	var result ValidationResult
+	result.Add(SeverityReject, file.Validate())
+	return result
+}
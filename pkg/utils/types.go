@@ -13,7 +13,7 @@ import (
 )
 
 //////////////////////////////////////////////
-//		Transmission File                   //
+//  Transmission File (TransmissionMessage) //
 //  MIME Multi-part Content Header          //
 //  MIME Part Boundary and Content Header   //
 //    SOAP Envelope (SOAPEnvelope)          //
@@ -39,6 +39,12 @@ type IrsReturnFile interface {
 	Version() string
 }
 
+// ValidationResulter is an optional interface of the return files that report
+// every validation issue with its severity, see FileValidationResult
+type ValidationResulter interface {
+	ValidationResult() ValidationResult
+}
+
 // interface for manifest
 type ManifestXml interface {
 	Validate() error
@@ -63,6 +69,7 @@ type Return interface {
 	InspectData() *ReturnInspectInfo
 	ReturnVersion() string
 	ReturnYear() int
+	StylesheetDir() string
 	ReturnType() string
 }
 
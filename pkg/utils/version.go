@@ -0,0 +1,61 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrUnsupportedElement is given when an element doesn't exist in the schema version of the return
+	ErrUnsupportedElement = errors.New("element doesn't exist in the return version")
+)
+
+// ValidateElements reports the first present element of r that isn't part of the schema version.
+// Unsupported elements are given as "<parent element>/<element>", e.g. "IRS990/OrganizationFollowsSFAS117Ind".
+func ValidateElements(r interface{}, unsupported map[string]bool) error {
+	if len(unsupported) == 0 {
+		return nil
+	}
+	return validateElements(reflect.ValueOf(r), "", unsupported)
+}
+
+func validateElements(data reflect.Value, parent string, unsupported map[string]bool) error {
+	switch data.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !data.IsNil() {
+			return validateElements(data.Elem(), parent, unsupported)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < data.Len(); i++ {
+			if err := validateElements(data.Index(i), parent, unsupported); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if data.Type().ConvertibleTo(timeType) {
+			return nil
+		}
+		for i := 0; i < data.NumField(); i++ {
+			field := data.Type().Field(i)
+			name := ElementName(field)
+			if name == "" || !field.IsExported() {
+				continue
+			}
+			path := parent + "/" + name
+			if unsupported[path] && !IsMissingValue(data.Field(i)) {
+				err := fmt.Errorf("%w: %s", ErrUnsupportedElement, path)
+				return withFieldError(CodeUnsupportedElement, data.Type(), field, data.Field(i), err)
+			}
+			if err := validateElements(data.Field(i), name, unsupported); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}